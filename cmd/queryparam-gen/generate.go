@@ -0,0 +1,625 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomwright/queryparam/v4"
+)
+
+// queryparamPath is the import path of the queryparam package.
+const queryparamPath = "github.com/tomwright/queryparam/v4"
+
+// The kinds of scalar value that can be parsed by generated code.
+const (
+	kindString  = "string"
+	kindInt     = "int"
+	kindInt32   = "int32"
+	kindInt64   = "int64"
+	kindFloat32 = "float32"
+	kindFloat64 = "float64"
+	kindBool    = "bool"
+	kindTime    = "time"
+	kindPresent = "present"
+)
+
+// kindTypes are the Go types of each kind.
+var kindTypes = map[string]reflect.Type{
+	kindString:  reflect.TypeOf(""),
+	kindInt:     reflect.TypeOf(0),
+	kindInt32:   reflect.TypeOf(int32(0)),
+	kindInt64:   reflect.TypeOf(int64(0)),
+	kindFloat32: reflect.TypeOf(float32(0)),
+	kindFloat64: reflect.TypeOf(float64(0)),
+	kindBool:    reflect.TypeOf(false),
+	kindTime:    reflect.TypeOf(time.Time{}),
+	kindPresent: reflect.TypeOf(queryparam.Present(false)),
+}
+
+// kindGoTypes are the Go type expressions of each kind, as used in generated code.
+var kindGoTypes = map[string]string{
+	kindString:  "string",
+	kindInt:     "int",
+	kindInt32:   "int32",
+	kindInt64:   "int64",
+	kindFloat32: "float32",
+	kindFloat64: "float64",
+	kindBool:    "bool",
+	kindTime:    "time.Time",
+	kindPresent: "queryparam.Present",
+}
+
+// parseSnippets parse {{in}} into {{out}}, setting err if it cannot be parsed.
+// They match the parsers returned by queryparam.DefaultValueParsers.
+var parseSnippets = map[string]string{
+	kindString: `{{out}} = {{in}}`,
+	kindInt: `if {{in}} != "" {
+	var i64 int64
+	if i64, err = strconv.ParseInt({{in}}, 10, 64); err == nil {
+		{{out}} = int(i64)
+	}
+}`,
+	kindInt32: `if {{in}} != "" {
+	var i64 int64
+	if i64, err = strconv.ParseInt({{in}}, 10, 32); err == nil {
+		{{out}} = int32(i64)
+	}
+}`,
+	kindInt64: `if {{in}} != "" {
+	{{out}}, err = strconv.ParseInt({{in}}, 10, 64)
+}`,
+	kindFloat32: `if {{in}} != "" {
+	var f64 float64
+	if f64, err = strconv.ParseFloat({{in}}, 64); err == nil {
+		{{out}} = float32(f64)
+	}
+}`,
+	kindFloat64: `if {{in}} != "" {
+	{{out}}, err = strconv.ParseFloat({{in}}, 64)
+}`,
+	kindBool: `switch strings.ToLower({{in}}) {
+case "true", "1", "y", "yes":
+	{{out}} = true
+case "", "false", "0", "n", "no":
+	{{out}} = false
+default:
+	err = queryparam.ErrInvalidBoolValue
+}`,
+	kindTime: `if {{in}} != "" {
+	{{out}}, err = time.Parse(time.RFC3339, {{in}})
+}`,
+	kindPresent: `{{out}} = queryparam.Present({{in}} != "")`,
+}
+
+// encodeSnippets encode {{in}} into a new string variable {{out}}.
+// They match the encoders returned by queryparam.DefaultValueEncoders.
+var encodeSnippets = map[string]string{
+	kindString:  `{{out}} := {{in}}`,
+	kindInt:     `{{out}} := strconv.FormatInt(int64({{in}}), 10)`,
+	kindInt32:   `{{out}} := strconv.FormatInt(int64({{in}}), 10)`,
+	kindInt64:   `{{out}} := strconv.FormatInt({{in}}, 10)`,
+	kindFloat32: `{{out}} := strconv.FormatFloat(float64({{in}}), 'f', -1, 32)`,
+	kindFloat64: `{{out}} := strconv.FormatFloat({{in}}, 'f', -1, 64)`,
+	kindBool:    `{{out}} := strconv.FormatBool({{in}})`,
+	kindTime: `{{out}} := ""
+if !{{in}}.IsZero() {
+	{{out}} = {{in}}.Format(time.RFC3339Nano)
+}`,
+	kindPresent: `{{out}} := ""
+if {{in}} {
+	{{out}} = "true"
+}`,
+}
+
+// snippet returns the given snippet with {{in}} and {{out}} replaced.
+func snippet(snippet string, in string, out string) string {
+	return strings.NewReplacer("{{in}}", in, "{{out}}", out).Replace(snippet)
+}
+
+// parseSnippet returns the parse snippet for the given kind, followed by a check that returns
+// the given error if the value could not be parsed. Kinds that cannot fail have no check.
+func parseSnippet(kind string, in string, out string, errReturn string) string {
+	code := snippet(parseSnippets[kind], in, out)
+	if !strings.Contains(code, "err") {
+		return code
+	}
+	return fmt.Sprintf("var err error\n%s\nif err != nil {\n%s\n}", code, errReturn)
+}
+
+// param is a query parameter that is read into a struct field.
+type param struct {
+	// name is the full parameter name.
+	name string
+	// path is the selector of the field from the struct, e.g. Page.Size.
+	path string
+	// field is the name of the field.
+	field string
+	// kind is the kind of the scalar value, or the slice items.
+	kind      string
+	pointer   bool
+	slice     bool
+	style     queryparam.Style
+	delimiter string
+	// defaultValue is nil if the field has no default.
+	defaultValue *string
+	required     bool
+}
+
+// structType is a struct that methods are generated for.
+type structType struct {
+	name   string
+	params []*param
+	// unmarshaler is true if the struct implements queryparam.QueryUnmarshaler.
+	unmarshaler bool
+}
+
+// generate returns the generated source for the given types in the package in dir.
+// If no types are given, every struct with a queryparam tag is used.
+// The file with the given output name is ignored when loading the package.
+func generate(dir string, typeNames []string, outputName string) ([]byte, error) {
+	pkg, err := loadPackage(dir, outputName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(typeNames) == 0 {
+		for _, name := range pkg.Scope().Names() {
+			if named, ok := structNamed(pkg.Scope().Lookup(name)); ok && hasTag(named.Underlying().(*types.Struct)) {
+				typeNames = append(typeNames, name)
+			}
+		}
+		if len(typeNames) == 0 {
+			return nil, fmt.Errorf("no structs with %s tags found in %s", queryparam.DefaultParser.Tag, dir)
+		}
+	}
+
+	structs := make([]*structType, len(typeNames))
+	for i, name := range typeNames {
+		named, ok := structNamed(pkg.Scope().Lookup(name))
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct type in %s", name, dir)
+		}
+		s := &structType{name: name}
+		if err := collectParams(s, "", "", named.Underlying().(*types.Struct)); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		s.unmarshaler = types.NewMethodSet(types.NewPointer(named)).Lookup(pkg, "UnmarshalQuery") != nil
+		structs[i] = s
+	}
+
+	body := &bytes.Buffer{}
+	for _, s := range structs {
+		writeParseQuery(body, s)
+		writeEncodeQuery(body, s)
+	}
+
+	imports, err := usedImports(body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	src := &bytes.Buffer{}
+	fmt.Fprintf(src, "// Code generated by queryparam-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	for _, imp := range []string{"net/url", "reflect", "strconv", "strings", "time"} {
+		if imports[path.Base(imp)] {
+			fmt.Fprintf(src, "%q\n", imp)
+		}
+	}
+	fmt.Fprintf(src, "\n%q\n)\n", queryparamPath)
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated source: %w", err)
+	}
+	return formatted, nil
+}
+
+// usedImports returns the set of package names that are referenced by the given declarations.
+func usedImports(body []byte) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", append([]byte("package p\n"), body...), 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse generated source: %w", err)
+	}
+	res := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				res[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return res, nil
+}
+
+// loadPackage parses and type checks the package in dir, ignoring the given output file.
+// Type errors are ignored so that a stale output file or code that uses the generated
+// methods does not prevent generation.
+func loadPackage(dir string, outputName string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range buildPkg.GoFiles {
+		if name == outputName {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(buildPkg.ImportPath, fset, files, nil)
+	return pkg, nil
+}
+
+// structNamed returns the named struct type of the given object.
+func structNamed(obj types.Object) (*types.Named, bool) {
+	typeName, ok := obj.(*types.TypeName)
+	if !ok || typeName.IsAlias() {
+		return nil, false
+	}
+	named, ok := typeName.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return nil, false
+	}
+	_, ok = named.Underlying().(*types.Struct)
+	return named, ok
+}
+
+// hasTag returns true if any field in the struct, or in an embedded struct, has a queryparam tag.
+func hasTag(s *types.Struct) bool {
+	for i := 0; i < s.NumFields(); i++ {
+		if _, ok := reflect.StructTag(s.Tag(i)).Lookup(queryparam.DefaultParser.Tag); ok {
+			return true
+		}
+		if embedded, ok := s.Field(i).Type().Underlying().(*types.Struct); ok && s.Field(i).Embedded() && hasTag(embedded) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectParams adds a param for each tagged field in the given struct, following the same
+// rules as the queryparam Parser.
+func collectParams(s *structType, prefix string, path string, st *types.Struct) error {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		fieldPath := field.Name()
+		if path != "" {
+			fieldPath = path + "." + fieldPath
+		}
+		structField := reflect.StructField{Name: field.Name(), Tag: reflect.StructTag(st.Tag(i))}
+
+		tag, ok := structField.Tag.Lookup(queryparam.DefaultParser.Tag)
+		if !ok {
+			if embedded, ok := field.Type().Underlying().(*types.Struct); ok && field.Embedded() {
+				if err := collectParams(s, prefix, fieldPath, embedded); err != nil {
+					return err
+				}
+			}
+			// the reflective parser rejects embedded struct pointers with tagged fields, so does the generator.
+			if pointer, ok := field.Type().Underlying().(*types.Pointer); ok && field.Embedded() {
+				if embedded, ok := pointer.Elem().Underlying().(*types.Struct); ok && hasTag(embedded) {
+					return fmt.Errorf("%w: %s: embedded struct pointers are not supported", queryparam.ErrUnhandledFieldType, field.Name())
+				}
+			}
+			continue
+		}
+		// the reflective parser cannot set unexported fields, so neither does the generated code.
+		if !field.Exported() {
+			return fmt.Errorf("field %s is not exported", field.Name())
+		}
+		parts := strings.Split(tag, ",")
+		if len(parts) > 1 {
+			return fmt.Errorf("unsupported tag option %q for field: %s", parts[1], field.Name())
+		}
+		if parts[0] == "" {
+			return fmt.Errorf("missing tag value for field: %s: %w", field.Name(), queryparam.ErrInvalidTag)
+		}
+		name := queryparam.DefaultParser.ParameterName(prefix, parts[0])
+
+		p := &param{
+			name:      name,
+			path:      fieldPath,
+			field:     field.Name(),
+			delimiter: queryparam.DefaultParser.FieldDelimiter(structField),
+		}
+		if !p.setType(field.Type()) {
+			if nested, ok := field.Type().Underlying().(*types.Struct); ok {
+				if _, ok := field.Type().(*types.Pointer); !ok {
+					if err := collectParams(s, name, fieldPath, nested); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			return fmt.Errorf("%w: %s: %v", queryparam.ErrUnhandledFieldType, field.Name(), field.Type())
+		}
+		if err := p.setTags(structField); err != nil {
+			return err
+		}
+		s.params = append(s.params, p)
+	}
+	return nil
+}
+
+// setType sets the kind, pointer and slice fields of the param from the given type,
+// returning false if the type is not supported.
+func (p *param) setType(t types.Type) bool {
+	if pointer, ok := t.(*types.Pointer); ok {
+		p.pointer = true
+		t = pointer.Elem()
+	}
+	if slice, ok := t.(*types.Slice); ok {
+		p.slice = true
+		t = slice.Elem()
+	}
+	p.kind = typeKind(t)
+	return p.kind != "" && !(p.slice && p.kind == kindPresent)
+}
+
+// typeKind returns the kind of the given type, or an empty string if it is not supported.
+func typeKind(t types.Type) string {
+	if basic, ok := t.(*types.Basic); ok {
+		switch basic.Kind() {
+		case types.String:
+			return kindString
+		case types.Int:
+			return kindInt
+		case types.Int32:
+			return kindInt32
+		case types.Int64:
+			return kindInt64
+		case types.Float32:
+			return kindFloat32
+		case types.Float64:
+			return kindFloat64
+		case types.Bool:
+			return kindBool
+		}
+		return ""
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	switch named.Obj().Pkg().Path() + "." + named.Obj().Name() {
+	case "time.Time":
+		return kindTime
+	case queryparamPath + ".Present":
+		return kindPresent
+	}
+	return ""
+}
+
+// setTags sets the style, default and required fields of the param from the field tags.
+func (p *param) setTags(field reflect.StructField) error {
+	style, err := queryparam.DefaultParser.FieldStyle(field)
+	if err != nil {
+		return err
+	}
+	p.style = style
+
+	if defaultValue, ok := field.Tag.Lookup(queryparam.DefaultParser.DefaultTag); ok {
+		if err := p.checkDefault(defaultValue); err != nil {
+			return fmt.Errorf("invalid default value %q for field: %s: %v: %w", defaultValue, field.Name, err, queryparam.ErrInvalidTag)
+		}
+		p.defaultValue = &defaultValue
+	}
+
+	ruleTags := queryparam.DefaultParser.RuleTags
+	if arg, ok := field.Tag.Lookup(ruleTags[queryparam.RuleRequired]); ok && arg == "" {
+		p.required = true
+	} else if ok {
+		if p.required, err = strconv.ParseBool(arg); err != nil {
+			return fmt.Errorf("invalid %s rule %q for field: %s: %w", queryparam.RuleRequired, arg, field.Name, queryparam.ErrInvalidTag)
+		}
+	}
+	if p.required && p.defaultValue != nil {
+		return fmt.Errorf("required field cannot have a default value: %s: %w", field.Name, queryparam.ErrInvalidTag)
+	}
+	for _, rule := range []string{queryparam.RuleMin, queryparam.RuleMax, queryparam.RuleMinLen, queryparam.RuleMaxLen, queryparam.RuleOneOf, queryparam.RulePattern} {
+		if _, ok := field.Tag.Lookup(ruleTags[rule]); ok {
+			return fmt.Errorf("unsupported %s rule for field: %s", rule, field.Name)
+		}
+	}
+	return nil
+}
+
+// checkDefault returns an error if the given default value cannot be parsed.
+func (p *param) checkDefault(defaultValue string) error {
+	items := []string{defaultValue}
+	if p.slice && p.style != queryparam.StyleExplode && defaultValue != "" {
+		items = strings.Split(defaultValue, p.delimiter)
+	}
+	valueParser := queryparam.DefaultValueParsers()[kindTypes[p.kind]]
+	for _, item := range items {
+		if _, err := valueParser(item, p.delimiter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeParseQuery writes the ParseQuery method of the given struct.
+func writeParseQuery(w *bytes.Buffer, s *structType) {
+	fmt.Fprintf(w, `
+// ParseQuery parses the query parameters into t in the same way as queryparam.Parse.
+func (t *%s) ParseQuery(urlValues url.Values) error {
+	if urlValues == nil {
+		return queryparam.ErrInvalidURLValues
+	}
+	if t == nil {
+		return queryparam.ErrNonPointerTarget
+	}
+`, s.name)
+	for _, p := range s.params {
+		writeParseParam(w, p)
+	}
+	if s.unmarshaler {
+		fmt.Fprintf(w, "return t.UnmarshalQuery(urlValues)\n}\n")
+	} else {
+		fmt.Fprintf(w, "return nil\n}\n")
+	}
+}
+
+// writeParseParam writes the code that parses a single param.
+func writeParseParam(w *bytes.Buffer, p *param) {
+	fmt.Fprintf(w, "\n// %s\n{\n", p.name)
+	if p.required || p.defaultValue != nil {
+		fmt.Fprintf(w, "values, ok := urlValues[%q]\n", p.name)
+	} else {
+		fmt.Fprintf(w, "values := urlValues[%q]\n", p.name)
+	}
+	if p.required {
+		fmt.Fprintf(w, `if !ok {
+	return &queryparam.ErrValidation{Parameter: %q, Field: %q, Rule: queryparam.RuleRequired, Arg: "true", Value: "", Type: reflect.TypeOf(t.%s)}
+}
+`, p.name, p.field, p.path)
+	}
+	if p.defaultValue != nil {
+		fmt.Fprintf(w, "if !ok {\nvalues = []string{%q}\n}\n", *p.defaultValue)
+	}
+	// the pointer is left nil if the parameter is absent, which cannot happen with a default.
+	pointer := p.pointer && p.defaultValue == nil
+	if pointer {
+		fmt.Fprintf(w, "if values == nil {\nt.%s = nil\n} else {\n", p.path)
+	}
+
+	goType := kindGoTypes[p.kind]
+	invalid := func(value string, err string) string {
+		return fmt.Sprintf("return &queryparam.ErrInvalidParameterValue{Err: %s, Parameter: %q, Field: %q, Value: %s, Type: reflect.TypeOf(t.%s)}", err, p.name, p.field, value, p.path)
+	}
+	const sliceItemErr = "&queryparam.ErrInvalidSliceItem{Err: err, Index: i, Value: item}"
+
+	switch {
+	case p.kind == kindPresent:
+		fmt.Fprintf(w, "v := queryparam.Present(values != nil)\n")
+	case !p.slice:
+		fmt.Fprintf(w, `var v %s
+value := ""
+if len(values) > 0 {
+	value = values[0]
+}
+%s
+`, goType, parseSnippet(p.kind, "value", "v", invalid("value", "err")))
+	case p.style == queryparam.StyleExplode:
+		fmt.Fprintf(w, `v := make([]%s, len(values))
+for i, item := range values {
+	%s
+}
+`, goType, parseSnippet(p.kind, "item", "v[i]", invalid("item", sliceItemErr)))
+	case p.style == queryparam.StyleDelimited:
+		fmt.Fprintf(w, `v := []%s{}
+value := ""
+if len(values) > 0 {
+	value = values[0]
+}
+if value != "" {
+	items := strings.Split(value, %q)
+	v = make([]%s, len(items))
+	for i, item := range items {
+		%s
+	}
+}
+`, goType, p.delimiter, goType, parseSnippet(p.kind, "item", "v[i]", invalid("value", sliceItemErr)))
+	default:
+		// the item index is the index within the resulting slice, across every value.
+		const bothItemErr = "&queryparam.ErrInvalidSliceItem{Err: err, Index: len(v), Value: item}"
+		fmt.Fprintf(w, `v := []%s{}
+for _, value := range values {
+	if value == "" {
+		continue
+	}
+	for _, item := range strings.Split(value, %q) {
+		var parsedItem %s
+		%s
+		v = append(v, parsedItem)
+	}
+}
+`, goType, p.delimiter, goType, parseSnippet(p.kind, "item", "parsedItem", invalid("value", bothItemErr)))
+	}
+
+	switch {
+	case pointer:
+		fmt.Fprintf(w, "t.%s = &v\n}\n", p.path)
+	case p.pointer:
+		fmt.Fprintf(w, "t.%s = &v\n", p.path)
+	default:
+		fmt.Fprintf(w, "t.%s = v\n", p.path)
+	}
+	fmt.Fprintf(w, "}\n")
+}
+
+// writeEncodeQuery writes the EncodeQuery method of the given struct.
+func writeEncodeQuery(w *bytes.Buffer, s *structType) {
+	fmt.Fprintf(w, `
+// EncodeQuery encodes t into url.Values in the same way as queryparam.Encode.
+func (t *%s) EncodeQuery() (url.Values, error) {
+	if t == nil {
+		return nil, queryparam.ErrNilSource
+	}
+	urlValues := url.Values{}
+`, s.name)
+	for _, p := range s.params {
+		writeEncodeParam(w, p)
+	}
+	fmt.Fprintf(w, "return urlValues, nil\n}\n")
+}
+
+// writeEncodeParam writes the code that encodes a single param.
+func writeEncodeParam(w *bytes.Buffer, p *param) {
+	fmt.Fprintf(w, "\n// %s\n", p.name)
+	if p.pointer {
+		fmt.Fprintf(w, "if t.%s != nil {\nv := *t.%s\n", p.path, p.path)
+	} else {
+		fmt.Fprintf(w, "{\nv := t.%s\n", p.path)
+	}
+
+	switch {
+	case !p.slice:
+		fmt.Fprintf(w, `%s
+if s != "" {
+	urlValues.Add(%q, s)
+}
+`, snippet(encodeSnippets[p.kind], "v", "s"), p.name)
+	case p.style == queryparam.StyleExplode:
+		fmt.Fprintf(w, `for _, item := range v {
+	%s
+	urlValues.Add(%q, s)
+}
+`, snippet(encodeSnippets[p.kind], "item", "s"), p.name)
+	default:
+		fmt.Fprintf(w, `items := make([]string, len(v))
+for i, item := range v {
+	%s
+	items[i] = s
+}
+if s := strings.Join(items, %q); s != "" {
+	urlValues.Add(%q, s)
+}
+`, snippet(encodeSnippets[p.kind], "item", "s"), p.delimiter, p.name)
+	}
+	fmt.Fprintf(w, "}\n")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tomwright/queryparam/v4"
+)
+
+func TestGenerate_Example(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	exp, err := os.ReadFile(filepath.Join(dir, "queryparam_gen.go"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := generate(dir, []string{"Search", "Scalars"}, "queryparam_gen.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(exp, got) {
+		t.Errorf("generated code is out of date. run go generate ./...")
+	}
+}
+
+func TestGenerate_AllTypes(t *testing.T) {
+	got, err := generate(filepath.Join("internal", "example"), nil, "queryparam_gen.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, name := range []string{"Search", "Scalars", "Page", "Common"} {
+		if !bytes.Contains(got, []byte("func (t *"+name+") ParseQuery(")) {
+			t.Errorf("expected ParseQuery to be generated for %s", name)
+		}
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	dir := filepath.Join("testdata", "invalid")
+	tests := []struct {
+		Type    string
+		Err     error
+		Message string
+	}{
+		{Type: "EmptyTag", Err: queryparam.ErrInvalidTag},
+		{Type: "UnhandledType", Err: queryparam.ErrUnhandledFieldType},
+		{Type: "CustomType", Err: queryparam.ErrUnhandledFieldType},
+		{Type: "Remain", Message: "unsupported tag option"},
+		{Type: "Validation", Message: "unsupported max rule"},
+		{Type: "InvalidDefault", Err: queryparam.ErrInvalidTag},
+		{Type: "RequiredWithDefault", Err: queryparam.ErrInvalidTag},
+		{Type: "InvalidStyle", Err: queryparam.ErrInvalidTag},
+		{Type: "Unexported", Message: "field size is not exported"},
+		{Type: "EmbeddedPointer", Err: queryparam.ErrUnhandledFieldType},
+		{Type: "ID", Message: "is not a struct type"},
+		{Type: "Missing", Message: "is not a struct type"},
+	}
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.Type, func(t *testing.T) {
+			_, err := generate(dir, []string{tc.Type}, "")
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if tc.Err != nil && !errors.Is(err, tc.Err) {
+				t.Errorf("expected error `%v`, got `%v`", tc.Err, err)
+			}
+			if tc.Message != "" && !strings.Contains(err.Error(), tc.Message) {
+				t.Errorf("expected error containing `%s`, got `%v`", tc.Message, err)
+			}
+		})
+	}
+}
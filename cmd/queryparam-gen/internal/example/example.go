@@ -0,0 +1,61 @@
+// Package example contains structs with generated query parameter methods, used to check that
+// the generated code behaves in the same way as the reflective queryparam Parser.
+package example
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/tomwright/queryparam/v4"
+)
+
+//go:generate go run github.com/tomwright/queryparam/v4/cmd/queryparam-gen -type=Search,Scalars
+
+// Page is a nested struct.
+type Page struct {
+	Size   int `queryparam:"size" default:"10"`
+	Number int `queryparam:"number"`
+}
+
+// Common is an embedded struct.
+type Common struct {
+	Debug queryparam.Present `queryparam:"debug"`
+}
+
+// Search uses slices, pointers, nested structs and tags.
+type Search struct {
+	Common
+	Query      string              `queryparam:"q" required:"true"`
+	IDs        []int               `queryparam:"id"`
+	Tags       []string            `queryparam:"tag" queryparamstyle:"explode"`
+	Scores     []float64           `queryparam:"score" queryparamstyle:"delimited" queryparamdelim:"|"`
+	Days       []time.Time         `queryparam:"day" queryparamstyle:"explode"`
+	Sort       *string             `queryparam:"sort" default:"asc"`
+	Limit      *int64              `queryparam:"limit"`
+	Categories *[]string           `queryparam:"category"`
+	Flag       *queryparam.Present `queryparam:"flag"`
+	Page       Page                `queryparam:"page"`
+	Ignored    string
+
+	// Unmarshaled is set by UnmarshalQuery.
+	Unmarshaled string
+}
+
+// UnmarshalQuery sets Unmarshaled from the raw parameter.
+func (s *Search) UnmarshalQuery(urlValues url.Values) error {
+	s.Unmarshaled = urlValues.Get("raw")
+	return nil
+}
+
+// Scalars uses every supported scalar type.
+type Scalars struct {
+	String  string             `queryparam:"string"`
+	Int     int                `queryparam:"int"`
+	Int32   int32              `queryparam:"int32"`
+	Int64   int64              `queryparam:"int64"`
+	Float32 float32            `queryparam:"float32"`
+	Float64 float64            `queryparam:"float64"`
+	Bool    bool               `queryparam:"bool"`
+	Time    time.Time          `queryparam:"time"`
+	Present queryparam.Present `queryparam:"present"`
+}
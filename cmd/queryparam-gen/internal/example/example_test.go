@@ -0,0 +1,181 @@
+package example_test
+
+import (
+	"github.com/tomwright/queryparam/v4"
+	"github.com/tomwright/queryparam/v4/cmd/queryparam-gen/internal/example"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSearch_ParseQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		Name string
+		URL  string
+	}{
+		{Name: "Empty", URL: ""},
+		{Name: "Required", URL: "q=tom"},
+		{Name: "MissingRequired", URL: "id=1"},
+		{Name: "Full", URL: "q=tom&debug&id=1,2&id=&id=3&tag=a&tag=&tag=b,c&score=1.5|2&day=2019-02-05T13:32:02Z&sort=desc&limit=5&category=x,y&flag=&page.size=20&page.number=2&raw=r"},
+		{Name: "EmptyValues", URL: "q=&id=&score=&sort=&limit=&category=&page.size="},
+		{Name: "InvalidSliceItem", URL: "q=tom&id=1,x"},
+		{Name: "InvalidRepeatedSliceItem", URL: "q=tom&id=1&id=&id=2,x"},
+		{Name: "InvalidExplodeItem", URL: "q=tom&day=2019-02-05T13:32:02Z&day=x"},
+		{Name: "InvalidDelimitedItem", URL: "q=tom&score=1|x"},
+		{Name: "InvalidPointer", URL: "q=tom&limit=x"},
+		{Name: "InvalidNested", URL: "q=tom&page.size=x"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			urlValues, err := url.ParseQuery(tc.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			exp := &example.Search{}
+			expErr := queryparam.Parse(urlValues, exp)
+			got := &example.Search{}
+			gotErr := got.ParseQuery(urlValues)
+
+			if !reflect.DeepEqual(expErr, gotErr) {
+				t.Errorf("expected error `%v`, got `%v`", expErr, gotErr)
+			}
+			if expErr == nil && !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected `%+v`, got `%+v`", exp, got)
+			}
+		})
+	}
+}
+
+func TestScalars_ParseQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []string{
+		"",
+		"string=a&int=1&int32=2&int64=3&float32=1.5&float64=2.5&bool=yes&time=2019-02-05T13:32:02Z&present",
+		"int=&int32=&int64=&float32=&float64=&bool=&time=",
+		"int=x",
+		"int32=2147483648",
+		"int64=x",
+		"float32=x",
+		"float64=x",
+		"bool=x",
+		"time=x",
+	}
+
+	for _, rawQuery := range tests {
+		urlValues, err := url.ParseQuery(rawQuery)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		exp := &example.Scalars{}
+		expErr := queryparam.Parse(urlValues, exp)
+		got := &example.Scalars{}
+		gotErr := got.ParseQuery(urlValues)
+
+		if !reflect.DeepEqual(expErr, gotErr) {
+			t.Errorf("%s: expected error `%v`, got `%v`", rawQuery, expErr, gotErr)
+		}
+		if expErr == nil && !reflect.DeepEqual(exp, got) {
+			t.Errorf("%s: expected `%+v`, got `%+v`", rawQuery, exp, got)
+		}
+	}
+}
+
+func TestParseQuery_InvalidURLValues(t *testing.T) {
+	t.Parallel()
+
+	if err := (&example.Search{}).ParseQuery(nil); err != queryparam.ErrInvalidURLValues {
+		t.Errorf("expected error `%v`, got `%v`", queryparam.ErrInvalidURLValues, err)
+	}
+}
+
+func TestEncodeQuery(t *testing.T) {
+	t.Parallel()
+
+	sort := "desc"
+	limit := int64(0)
+	categories := []string{"x", "y"}
+	flag := queryparam.Present(true)
+	sources := []interface {
+		EncodeQuery() (url.Values, error)
+	}{
+		&example.Search{},
+		&example.Search{
+			Common:     example.Common{Debug: true},
+			Query:      "tom",
+			IDs:        []int{1, 2},
+			Tags:       []string{"a", "", "b"},
+			Scores:     []float64{1.5, 2},
+			Days:       []time.Time{time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC), {}},
+			Sort:       &sort,
+			Limit:      &limit,
+			Categories: &categories,
+			Flag:       &flag,
+			Page:       example.Page{Size: 20},
+		},
+		&example.Scalars{},
+		&example.Scalars{
+			String:  "a",
+			Int:     1,
+			Int32:   2,
+			Int64:   3,
+			Float32: 1.5,
+			Float64: 2.5,
+			Bool:    true,
+			Time:    time.Date(2019, 2, 5, 13, 32, 2, 5, time.UTC),
+			Present: true,
+		},
+	}
+
+	for _, source := range sources {
+		exp, expErr := queryparam.Encode(source)
+		got, gotErr := source.EncodeQuery()
+		if !reflect.DeepEqual(expErr, gotErr) {
+			t.Errorf("expected error `%v`, got `%v`", expErr, gotErr)
+		}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected `%v`, got `%v`", exp, got)
+		}
+	}
+}
+
+func TestEncodeQuery_NilSource(t *testing.T) {
+	t.Parallel()
+
+	var search *example.Search
+	if _, err := search.EncodeQuery(); err != queryparam.ErrNilSource {
+		t.Errorf("expected error `%v`, got `%v`", queryparam.ErrNilSource, err)
+	}
+}
+
+var benchmarkValues = url.Values{
+	"q":         []string{"tom"},
+	"id":        []string{"1,2,3"},
+	"tag":       []string{"a", "b"},
+	"limit":     []string{"10"},
+	"page.size": []string{"20"},
+}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := queryparam.Parse(benchmarkValues, &example.Search{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSearch_ParseQuery(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := (&example.Search{}).ParseQuery(benchmarkValues); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,640 @@
+// Code generated by queryparam-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tomwright/queryparam/v4"
+)
+
+// ParseQuery parses the query parameters into t in the same way as queryparam.Parse.
+func (t *Search) ParseQuery(urlValues url.Values) error {
+	if urlValues == nil {
+		return queryparam.ErrInvalidURLValues
+	}
+	if t == nil {
+		return queryparam.ErrNonPointerTarget
+	}
+
+	// debug
+	{
+		values := urlValues["debug"]
+		v := queryparam.Present(values != nil)
+		t.Common.Debug = v
+	}
+
+	// q
+	{
+		values, ok := urlValues["q"]
+		if !ok {
+			return &queryparam.ErrValidation{Parameter: "q", Field: "Query", Rule: queryparam.RuleRequired, Arg: "true", Value: "", Type: reflect.TypeOf(t.Query)}
+		}
+		var v string
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		v = value
+		t.Query = v
+	}
+
+	// id
+	{
+		values := urlValues["id"]
+		v := []int{}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			for _, item := range strings.Split(value, ",") {
+				var parsedItem int
+				var err error
+				if item != "" {
+					var i64 int64
+					if i64, err = strconv.ParseInt(item, 10, 64); err == nil {
+						parsedItem = int(i64)
+					}
+				}
+				if err != nil {
+					return &queryparam.ErrInvalidParameterValue{Err: &queryparam.ErrInvalidSliceItem{Err: err, Index: len(v), Value: item}, Parameter: "id", Field: "IDs", Value: value, Type: reflect.TypeOf(t.IDs)}
+				}
+				v = append(v, parsedItem)
+			}
+		}
+		t.IDs = v
+	}
+
+	// tag
+	{
+		values := urlValues["tag"]
+		v := make([]string, len(values))
+		for i, item := range values {
+			v[i] = item
+		}
+		t.Tags = v
+	}
+
+	// score
+	{
+		values := urlValues["score"]
+		v := []float64{}
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		if value != "" {
+			items := strings.Split(value, "|")
+			v = make([]float64, len(items))
+			for i, item := range items {
+				var err error
+				if item != "" {
+					v[i], err = strconv.ParseFloat(item, 64)
+				}
+				if err != nil {
+					return &queryparam.ErrInvalidParameterValue{Err: &queryparam.ErrInvalidSliceItem{Err: err, Index: i, Value: item}, Parameter: "score", Field: "Scores", Value: value, Type: reflect.TypeOf(t.Scores)}
+				}
+			}
+		}
+		t.Scores = v
+	}
+
+	// day
+	{
+		values := urlValues["day"]
+		v := make([]time.Time, len(values))
+		for i, item := range values {
+			var err error
+			if item != "" {
+				v[i], err = time.Parse(time.RFC3339, item)
+			}
+			if err != nil {
+				return &queryparam.ErrInvalidParameterValue{Err: &queryparam.ErrInvalidSliceItem{Err: err, Index: i, Value: item}, Parameter: "day", Field: "Days", Value: item, Type: reflect.TypeOf(t.Days)}
+			}
+		}
+		t.Days = v
+	}
+
+	// sort
+	{
+		values, ok := urlValues["sort"]
+		if !ok {
+			values = []string{"asc"}
+		}
+		var v string
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		v = value
+		t.Sort = &v
+	}
+
+	// limit
+	{
+		values := urlValues["limit"]
+		if values == nil {
+			t.Limit = nil
+		} else {
+			var v int64
+			value := ""
+			if len(values) > 0 {
+				value = values[0]
+			}
+			var err error
+			if value != "" {
+				v, err = strconv.ParseInt(value, 10, 64)
+			}
+			if err != nil {
+				return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "limit", Field: "Limit", Value: value, Type: reflect.TypeOf(t.Limit)}
+			}
+			t.Limit = &v
+		}
+	}
+
+	// category
+	{
+		values := urlValues["category"]
+		if values == nil {
+			t.Categories = nil
+		} else {
+			v := []string{}
+			for _, value := range values {
+				if value == "" {
+					continue
+				}
+				for _, item := range strings.Split(value, ",") {
+					var parsedItem string
+					parsedItem = item
+					v = append(v, parsedItem)
+				}
+			}
+			t.Categories = &v
+		}
+	}
+
+	// flag
+	{
+		values := urlValues["flag"]
+		if values == nil {
+			t.Flag = nil
+		} else {
+			v := queryparam.Present(values != nil)
+			t.Flag = &v
+		}
+	}
+
+	// page.size
+	{
+		values, ok := urlValues["page.size"]
+		if !ok {
+			values = []string{"10"}
+		}
+		var v int
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			var i64 int64
+			if i64, err = strconv.ParseInt(value, 10, 64); err == nil {
+				v = int(i64)
+			}
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "page.size", Field: "Size", Value: value, Type: reflect.TypeOf(t.Page.Size)}
+		}
+		t.Page.Size = v
+	}
+
+	// page.number
+	{
+		values := urlValues["page.number"]
+		var v int
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			var i64 int64
+			if i64, err = strconv.ParseInt(value, 10, 64); err == nil {
+				v = int(i64)
+			}
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "page.number", Field: "Number", Value: value, Type: reflect.TypeOf(t.Page.Number)}
+		}
+		t.Page.Number = v
+	}
+	return t.UnmarshalQuery(urlValues)
+}
+
+// EncodeQuery encodes t into url.Values in the same way as queryparam.Encode.
+func (t *Search) EncodeQuery() (url.Values, error) {
+	if t == nil {
+		return nil, queryparam.ErrNilSource
+	}
+	urlValues := url.Values{}
+
+	// debug
+	{
+		v := t.Common.Debug
+		s := ""
+		if v {
+			s = "true"
+		}
+		if s != "" {
+			urlValues.Add("debug", s)
+		}
+	}
+
+	// q
+	{
+		v := t.Query
+		s := v
+		if s != "" {
+			urlValues.Add("q", s)
+		}
+	}
+
+	// id
+	{
+		v := t.IDs
+		items := make([]string, len(v))
+		for i, item := range v {
+			s := strconv.FormatInt(int64(item), 10)
+			items[i] = s
+		}
+		if s := strings.Join(items, ","); s != "" {
+			urlValues.Add("id", s)
+		}
+	}
+
+	// tag
+	{
+		v := t.Tags
+		for _, item := range v {
+			s := item
+			urlValues.Add("tag", s)
+		}
+	}
+
+	// score
+	{
+		v := t.Scores
+		items := make([]string, len(v))
+		for i, item := range v {
+			s := strconv.FormatFloat(item, 'f', -1, 64)
+			items[i] = s
+		}
+		if s := strings.Join(items, "|"); s != "" {
+			urlValues.Add("score", s)
+		}
+	}
+
+	// day
+	{
+		v := t.Days
+		for _, item := range v {
+			s := ""
+			if !item.IsZero() {
+				s = item.Format(time.RFC3339Nano)
+			}
+			urlValues.Add("day", s)
+		}
+	}
+
+	// sort
+	if t.Sort != nil {
+		v := *t.Sort
+		s := v
+		if s != "" {
+			urlValues.Add("sort", s)
+		}
+	}
+
+	// limit
+	if t.Limit != nil {
+		v := *t.Limit
+		s := strconv.FormatInt(v, 10)
+		if s != "" {
+			urlValues.Add("limit", s)
+		}
+	}
+
+	// category
+	if t.Categories != nil {
+		v := *t.Categories
+		items := make([]string, len(v))
+		for i, item := range v {
+			s := item
+			items[i] = s
+		}
+		if s := strings.Join(items, ","); s != "" {
+			urlValues.Add("category", s)
+		}
+	}
+
+	// flag
+	if t.Flag != nil {
+		v := *t.Flag
+		s := ""
+		if v {
+			s = "true"
+		}
+		if s != "" {
+			urlValues.Add("flag", s)
+		}
+	}
+
+	// page.size
+	{
+		v := t.Page.Size
+		s := strconv.FormatInt(int64(v), 10)
+		if s != "" {
+			urlValues.Add("page.size", s)
+		}
+	}
+
+	// page.number
+	{
+		v := t.Page.Number
+		s := strconv.FormatInt(int64(v), 10)
+		if s != "" {
+			urlValues.Add("page.number", s)
+		}
+	}
+	return urlValues, nil
+}
+
+// ParseQuery parses the query parameters into t in the same way as queryparam.Parse.
+func (t *Scalars) ParseQuery(urlValues url.Values) error {
+	if urlValues == nil {
+		return queryparam.ErrInvalidURLValues
+	}
+	if t == nil {
+		return queryparam.ErrNonPointerTarget
+	}
+
+	// string
+	{
+		values := urlValues["string"]
+		var v string
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		v = value
+		t.String = v
+	}
+
+	// int
+	{
+		values := urlValues["int"]
+		var v int
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			var i64 int64
+			if i64, err = strconv.ParseInt(value, 10, 64); err == nil {
+				v = int(i64)
+			}
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "int", Field: "Int", Value: value, Type: reflect.TypeOf(t.Int)}
+		}
+		t.Int = v
+	}
+
+	// int32
+	{
+		values := urlValues["int32"]
+		var v int32
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			var i64 int64
+			if i64, err = strconv.ParseInt(value, 10, 32); err == nil {
+				v = int32(i64)
+			}
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "int32", Field: "Int32", Value: value, Type: reflect.TypeOf(t.Int32)}
+		}
+		t.Int32 = v
+	}
+
+	// int64
+	{
+		values := urlValues["int64"]
+		var v int64
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			v, err = strconv.ParseInt(value, 10, 64)
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "int64", Field: "Int64", Value: value, Type: reflect.TypeOf(t.Int64)}
+		}
+		t.Int64 = v
+	}
+
+	// float32
+	{
+		values := urlValues["float32"]
+		var v float32
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			var f64 float64
+			if f64, err = strconv.ParseFloat(value, 64); err == nil {
+				v = float32(f64)
+			}
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "float32", Field: "Float32", Value: value, Type: reflect.TypeOf(t.Float32)}
+		}
+		t.Float32 = v
+	}
+
+	// float64
+	{
+		values := urlValues["float64"]
+		var v float64
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			v, err = strconv.ParseFloat(value, 64)
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "float64", Field: "Float64", Value: value, Type: reflect.TypeOf(t.Float64)}
+		}
+		t.Float64 = v
+	}
+
+	// bool
+	{
+		values := urlValues["bool"]
+		var v bool
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		switch strings.ToLower(value) {
+		case "true", "1", "y", "yes":
+			v = true
+		case "", "false", "0", "n", "no":
+			v = false
+		default:
+			err = queryparam.ErrInvalidBoolValue
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "bool", Field: "Bool", Value: value, Type: reflect.TypeOf(t.Bool)}
+		}
+		t.Bool = v
+	}
+
+	// time
+	{
+		values := urlValues["time"]
+		var v time.Time
+		value := ""
+		if len(values) > 0 {
+			value = values[0]
+		}
+		var err error
+		if value != "" {
+			v, err = time.Parse(time.RFC3339, value)
+		}
+		if err != nil {
+			return &queryparam.ErrInvalidParameterValue{Err: err, Parameter: "time", Field: "Time", Value: value, Type: reflect.TypeOf(t.Time)}
+		}
+		t.Time = v
+	}
+
+	// present
+	{
+		values := urlValues["present"]
+		v := queryparam.Present(values != nil)
+		t.Present = v
+	}
+	return nil
+}
+
+// EncodeQuery encodes t into url.Values in the same way as queryparam.Encode.
+func (t *Scalars) EncodeQuery() (url.Values, error) {
+	if t == nil {
+		return nil, queryparam.ErrNilSource
+	}
+	urlValues := url.Values{}
+
+	// string
+	{
+		v := t.String
+		s := v
+		if s != "" {
+			urlValues.Add("string", s)
+		}
+	}
+
+	// int
+	{
+		v := t.Int
+		s := strconv.FormatInt(int64(v), 10)
+		if s != "" {
+			urlValues.Add("int", s)
+		}
+	}
+
+	// int32
+	{
+		v := t.Int32
+		s := strconv.FormatInt(int64(v), 10)
+		if s != "" {
+			urlValues.Add("int32", s)
+		}
+	}
+
+	// int64
+	{
+		v := t.Int64
+		s := strconv.FormatInt(v, 10)
+		if s != "" {
+			urlValues.Add("int64", s)
+		}
+	}
+
+	// float32
+	{
+		v := t.Float32
+		s := strconv.FormatFloat(float64(v), 'f', -1, 32)
+		if s != "" {
+			urlValues.Add("float32", s)
+		}
+	}
+
+	// float64
+	{
+		v := t.Float64
+		s := strconv.FormatFloat(v, 'f', -1, 64)
+		if s != "" {
+			urlValues.Add("float64", s)
+		}
+	}
+
+	// bool
+	{
+		v := t.Bool
+		s := strconv.FormatBool(v)
+		if s != "" {
+			urlValues.Add("bool", s)
+		}
+	}
+
+	// time
+	{
+		v := t.Time
+		s := ""
+		if !v.IsZero() {
+			s = v.Format(time.RFC3339Nano)
+		}
+		if s != "" {
+			urlValues.Add("time", s)
+		}
+	}
+
+	// present
+	{
+		v := t.Present
+		s := ""
+		if v {
+			s = "true"
+		}
+		if s != "" {
+			urlValues.Add("present", s)
+		}
+	}
+	return urlValues, nil
+}
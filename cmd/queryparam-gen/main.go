@@ -0,0 +1,56 @@
+// Command queryparam-gen generates reflection-free query parameter parsers for structs tagged
+// for use with queryparam.
+//
+// For each struct it generates a ParseQuery(url.Values) error method and an
+// EncodeQuery() (url.Values, error) method, which produce the same results and errors as
+// queryparam.Parse and queryparam.Encode with the queryparam.DefaultParser.
+//
+// It is intended to be used with go:generate:
+//
+//	//go:generate go run github.com/tomwright/queryparam/v4/cmd/queryparam-gen -type=SearchRequest
+//
+// Fields must use the types handled by queryparam.DefaultValueParsers, pointers to them, slices
+// of them, or nested structs. Optional fields, custom types, remain fields and validation rules
+// other than required are not supported; use the reflective Parser for those types.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of type names. defaults to every struct with a queryparam tag")
+	output := flag.String("output", "", "output file name. defaults to queryparam_gen.go in the package directory")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: queryparam-gen [flags] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	outputName := *output
+	if outputName == "" {
+		outputName = filepath.Join(dir, "queryparam_gen.go")
+	}
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	src, err := generate(dir, types, filepath.Base(outputName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "queryparam-gen: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputName, src, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "queryparam-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,52 @@
+package invalid
+
+type EmptyTag struct {
+	Name string `queryparam:""`
+}
+
+type UnhandledType struct {
+	Names map[string]string `queryparam:"names"`
+}
+
+type CustomType struct {
+	ID ID `queryparam:"id"`
+}
+
+type ID string
+
+type Remain struct {
+	Other map[string][]string `queryparam:",remain"`
+}
+
+type Validation struct {
+	Limit int `queryparam:"limit" max:"100"`
+}
+
+type InvalidDefault struct {
+	Limit int `queryparam:"limit" default:"x"`
+}
+
+type RequiredWithDefault struct {
+	Limit int `queryparam:"limit" required:"true" default:"10"`
+}
+
+type InvalidStyle struct {
+	IDs []int `queryparam:"id" queryparamstyle:"x"`
+}
+
+type Unexported struct {
+	size int `queryparam:"size"`
+}
+
+type Common struct {
+	Name string `queryparam:"name"`
+}
+
+type EmbeddedPointer struct {
+	*Common
+	Size int `queryparam:"size"`
+}
+
+type Untagged struct {
+	Name string
+}
@@ -0,0 +1,50 @@
+package queryparam
+
+import (
+	"context"
+	"net/http"
+)
+
+// contextKey is the key used to store a parsed T in a context.Context.
+// Each T has its own key, so parameters of different types can be stored alongside each other.
+type contextKey[T any] struct{}
+
+// NewContext returns a copy of ctx that carries the given parsed parameters.
+func NewContext[T any](ctx context.Context, params T) context.Context {
+	return context.WithValue(ctx, contextKey[T]{}, params)
+}
+
+// FromContext returns the parsed parameters of type T stored in ctx by Middleware or
+// NewContext, and whether or not they were found.
+func FromContext[T any](ctx context.Context) (T, bool) {
+	params, ok := ctx.Value(contextKey[T]{}).(T)
+	return params, ok
+}
+
+// Middleware returns middleware that binds the query parameters of each request into a new T
+// using the DefaultParser and stores it in the request context, where it can be read with
+// FromContext. If binding fails the error is written using DefaultErrorResponder and the next
+// handler is not called.
+func Middleware[T any](next http.Handler) http.Handler {
+	return MiddlewareWith[T](DefaultParser, DefaultErrorResponder, next)
+}
+
+// MiddlewareWith returns middleware that binds the query parameters of each request into a new
+// T using the given Parser and stores it in the request context, where it can be read with
+// FromContext. If binding fails the error is written using the given ErrorResponder and the
+// next handler is not called.
+// If the request context already contains a T it is not parsed again.
+func MiddlewareWith[T any](p *Parser, responder ErrorResponder, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := FromContext[T](r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		var params T
+		if err := p.BindRequest(r, &params); err != nil {
+			responder(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), params)))
+	})
+}
@@ -0,0 +1,75 @@
+package queryparam_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/tomwright/queryparam/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := queryparam.NewContext(context.Background(), httpRequest{Name: "tom"})
+
+	got, ok := queryparam.FromContext[httpRequest](ctx)
+	if !ok {
+		t.Errorf("expected params to be found")
+	}
+	if exp := (httpRequest{Name: "tom"}); exp != got {
+		t.Errorf("expected `%v`, got `%v`", exp, got)
+	}
+
+	if _, ok := queryparam.FromContext[*httpRequest](ctx); ok {
+		t.Errorf("expected params of a different type not to be found")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	var middlewareParams httpRequest
+	handler := queryparam.Middleware[httpRequest](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params, ok := queryparam.FromContext[httpRequest](r.Context())
+		if !ok {
+			t.Errorf("expected params to be found")
+		}
+		middlewareParams = params
+		fmt.Fprintf(w, "%s %d", params.Name, params.Limit)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?name=tom&limit=10", nil))
+	if exp, got := http.StatusOK, w.Code; exp != got {
+		t.Errorf("expected status code %d, got %d", exp, got)
+	}
+	if exp := (httpRequest{Name: "tom", Limit: 10}); exp != middlewareParams {
+		t.Errorf("expected `%v`, got `%v`", exp, middlewareParams)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?limit=x", nil))
+	if exp, got := http.StatusBadRequest, w.Code; exp != got {
+		t.Errorf("expected status code %d, got %d", exp, got)
+	}
+}
+
+func TestMiddleware_Handler(t *testing.T) {
+	t.Parallel()
+
+	parser := &queryparam.Parser{}
+	handler := queryparam.MiddlewareWith[httpRequest](queryparam.DefaultParser, queryparam.DefaultErrorResponder,
+		// an empty parser does not read any fields, so the params must come from the context.
+		queryparam.HandlerWith(parser, queryparam.DefaultErrorResponder, func(w http.ResponseWriter, r *http.Request, params httpRequest) {
+			fmt.Fprintf(w, "%s %d", params.Name, params.Limit)
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?name=tom&limit=10", nil))
+	if exp, got := "tom 10", w.Body.String(); exp != got {
+		t.Errorf("expected body `%s`, got `%s`", exp, got)
+	}
+}
@@ -0,0 +1,55 @@
+package queryparam
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// Decoder parses query parameters into a T using a type plan that is compiled once when the
+// Decoder is created, so tag and parser errors are found at startup rather than on the first
+// request.
+// The Decoder does not see changes made to the Parser's ValueParsers or ValueSetters after
+// it has been created.
+type Decoder[T any] struct {
+	parser *Parser
+	plan   *typePlan
+}
+
+// NewDecoder returns a Decoder that uses the given Parser to parse query parameters into a T.
+// T must be a struct type.
+func NewDecoder[T any](p *Parser) (*Decoder[T], error) {
+	plan, err := p.typePlan(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
+	return &Decoder[T]{
+		parser: p,
+		plan:   plan,
+	}, nil
+}
+
+// Decode parses the query parameters into a new T.
+func (d *Decoder[T]) Decode(urlValues url.Values) (T, error) {
+	var target T
+	err := d.DecodeInto(urlValues, &target)
+	return target, err
+}
+
+// DecodeInto parses the query parameters into the given target.
+func (d *Decoder[T]) DecodeInto(urlValues url.Values, target *T) error {
+	if urlValues == nil {
+		return ErrInvalidURLValues
+	}
+	if target == nil {
+		return ErrNonPointerTarget
+	}
+	return d.parser.parsePlan(d.plan, urlValues, nil, reflect.ValueOf(target), nil)
+}
+
+// ParseAs attempts to parse query parameters from the specified URL into a new T using the
+// DefaultParser.
+func ParseAs[T any](urlValues url.Values) (T, error) {
+	var target T
+	err := DefaultParser.Parse(urlValues, &target)
+	return target, err
+}
@@ -0,0 +1,116 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type decoderRequest struct {
+	Name string    `queryparam:"name"`
+	Age  int       `queryparam:"age"`
+	Page parsePage `queryparam:"page"`
+}
+
+func TestParseAs(t *testing.T) {
+	t.Parallel()
+
+	got, err := queryparam.ParseAs[decoderRequest](urlValuesNameAge)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp := (decoderRequest{Name: "tom", Age: 26}); exp != got {
+		t.Errorf("expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParseAs_NonStruct(t *testing.T) {
+	t.Parallel()
+
+	_, err := queryparam.ParseAs[*decoderRequest](urlValuesNameAge)
+	if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	decoder, err := queryparam.NewDecoder[decoderRequest](queryparam.DefaultParser)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	t.Run("Decode", func(t *testing.T) {
+		got, err := decoder.Decode(url.Values{"name": []string{"tom"}, "page.size": []string{"10"}})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := decoderRequest{Name: "tom", Page: parsePage{Size: 10}}
+		if exp != got {
+			t.Errorf("expected `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("DecodeInto", func(t *testing.T) {
+		got := &decoderRequest{}
+		if err := decoder.DecodeInto(urlValuesNameAge, got); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := &decoderRequest{Name: "tom", Age: 26}
+		if !reflect.DeepEqual(exp, got) {
+			t.Errorf("expected `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("InvalidParameter", func(t *testing.T) {
+		_, err := decoder.Decode(url.Values{"age": []string{"x"}})
+		var paramErr *queryparam.ErrInvalidParameterValue
+		if !errors.As(err, &paramErr) || paramErr.Parameter != "age" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("InvalidURLValues", func(t *testing.T) {
+		_, err := decoder.Decode(nil)
+		if exp, got := queryparam.ErrInvalidURLValues, err; exp != got {
+			t.Errorf("unexpected error. expected `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("NilTarget", func(t *testing.T) {
+		err := decoder.DecodeInto(urlValuesNameAge, nil)
+		if exp, got := queryparam.ErrNonPointerTarget, err; exp != got {
+			t.Errorf("unexpected error. expected `%v`, got `%v`", exp, got)
+		}
+	})
+}
+
+func TestNewDecoder_InvalidTag(t *testing.T) {
+	t.Parallel()
+
+	type invalid struct {
+		Name string `queryparam:""`
+	}
+
+	_, err := queryparam.NewDecoder[invalid](queryparam.DefaultParser)
+	if !errors.Is(err, queryparam.ErrInvalidTag) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func BenchmarkDecoder_Decode(b *testing.B) {
+	decoder, err := queryparam.NewDecoder[benchmarkRequest](queryparam.DefaultParser)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := decoder.Decode(urlValuesBenchmark); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package queryparam
+
+import (
+	"reflect"
+)
+
+// ParameterInfo describes a query parameter that is read by a Parser, as compiled from the
+// tags of a struct field. It can be used to generate documentation or schemas.
+type ParameterInfo struct {
+	// Name is the full parameter name, including the prefix of any parent structs.
+	Name  string
+	Field reflect.StructField
+	// Type is the type of the parameter value. Pointer and Optional types are unwrapped.
+	Type reflect.Type
+	// Slice is true if the value is a slice that is read using Style and Delimiter.
+	Slice     bool
+	Style     Style
+	Delimiter string
+	// Default is the value of the default tag. It is only used if HasDefault is true.
+	Default    string
+	HasDefault bool
+	Required   bool
+	// Rules are the validation rules of the parameter, other than required, in the order
+	// they are checked.
+	Rules []ParameterRule
+}
+
+// ParameterRule describes a validation rule, e.g. min:"1".
+type ParameterRule struct {
+	Name string
+	Arg  string
+}
+
+// Describe returns a description of each query parameter read into the given target, in the
+// order they are parsed. The target may be a struct or a pointer to a struct, and may be nil.
+// Remain fields are not included.
+func (p *Parser) Describe(target interface{}) ([]ParameterInfo, error) {
+	plan, err := p.targetPlan(target)
+	if err != nil {
+		return nil, err
+	}
+	return plan.describe(), nil
+}
+
+// Describe returns a description of each query parameter read into the given target using
+// the DefaultParser.
+func Describe(target interface{}) ([]ParameterInfo, error) {
+	return DefaultParser.Describe(target)
+}
+
+// targetPlan returns the plan for the type of the given target, which may be a struct or a
+// pointer to a struct.
+func (p *Parser) targetPlan(target interface{}) (*typePlan, error) {
+	t := reflect.TypeOf(target)
+	if t == nil {
+		return nil, ErrNonPointerTarget
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return p.typePlan(t)
+}
+
+// describe returns a description of each field in the plan.
+func (plan *typePlan) describe() []ParameterInfo {
+	res := make([]ParameterInfo, len(plan.fields))
+	for i, fieldPlan := range plan.fields {
+		info := ParameterInfo{
+			Name:      fieldPlan.name,
+			Field:     fieldPlan.field,
+			Type:      valueType(fieldPlan.field.Type),
+			Style:     fieldPlan.style,
+			Delimiter: fieldPlan.delimiter,
+			Required:  fieldPlan.required,
+		}
+		info.Slice = isSliceType(info.Type)
+		if fieldPlan.defaultValues != nil {
+			info.Default, info.HasDefault = fieldPlan.defaultValues[0], true
+		}
+		for _, rule := range fieldPlan.rules {
+			info.Rules = append(info.Rules, ParameterRule{Name: rule.name, Arg: rule.arg})
+		}
+		res[i] = info
+	}
+	return res
+}
+
+// valueType returns the type of the value held by the given type, unwrapping any pointer and
+// Optional types.
+func valueType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr || isOptionalType(t) {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		} else {
+			t = t.Field(optionalValueIndex).Type
+		}
+	}
+	return t
+}
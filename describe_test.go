@@ -0,0 +1,69 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"reflect"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		Size int `queryparam:"size" default:"10" min:"1" max:"100"`
+	}
+	type request struct {
+		Name  *string                     `queryparam:"name" required:"true"`
+		IDs   []int                       `queryparam:"id" queryparamstyle:"delimited" queryparamdelim:"|"`
+		Sort  queryparam.Optional[string] `queryparam:"sort" oneof:"asc desc"`
+		Page  page                        `queryparam:"page"`
+		Other map[string][]string         `queryparam:",remain"`
+	}
+
+	infos, err := queryparam.Describe(&request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := []struct {
+		Name       string
+		Type       reflect.Type
+		Slice      bool
+		Style      queryparam.Style
+		Delimiter  string
+		Default    string
+		HasDefault bool
+		Required   bool
+		Rules      []queryparam.ParameterRule
+	}{
+		{Name: "name", Type: reflect.TypeOf(""), Style: queryparam.StyleBoth, Delimiter: ",", Required: true},
+		{Name: "id", Type: reflect.TypeOf([]int{}), Slice: true, Style: queryparam.StyleDelimited, Delimiter: "|"},
+		{Name: "sort", Type: reflect.TypeOf(""), Style: queryparam.StyleBoth, Delimiter: ",", Rules: []queryparam.ParameterRule{{Name: "oneof", Arg: "asc desc"}}},
+		{Name: "page.size", Type: reflect.TypeOf(0), Style: queryparam.StyleBoth, Delimiter: ",", Default: "10", HasDefault: true, Rules: []queryparam.ParameterRule{{Name: "min", Arg: "1"}, {Name: "max", Arg: "100"}}},
+	}
+	if len(exp) != len(infos) {
+		t.Errorf("expected %d parameters, got %d", len(exp), len(infos))
+		return
+	}
+	for i, info := range infos {
+		e := exp[i]
+		if e.Name != info.Name || e.Type != info.Type || e.Slice != info.Slice || e.Style != info.Style ||
+			e.Delimiter != info.Delimiter || e.Default != info.Default || e.HasDefault != info.HasDefault ||
+			e.Required != info.Required || !reflect.DeepEqual(e.Rules, info.Rules) {
+			t.Errorf("unexpected parameter %d. expected `%v`, got `%v`", i, e, info)
+		}
+	}
+}
+
+func TestDescribe_InvalidTarget(t *testing.T) {
+	t.Parallel()
+
+	if _, err := queryparam.Describe(nil); !errors.Is(err, queryparam.ErrNonPointerTarget) {
+		t.Errorf("expected error `%v`, got `%v`", queryparam.ErrNonPointerTarget, err)
+	}
+	if _, err := queryparam.Describe(1); !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+		t.Errorf("expected error `%v`, got `%v`", queryparam.ErrUnhandledFieldType, err)
+	}
+}
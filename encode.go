@@ -0,0 +1,135 @@
+package queryparam
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+)
+
+// ErrNilSource is returned when the given source is a nil pointer.
+var ErrNilSource = errors.New("invalid source. must not be a nil pointer")
+
+// ErrCannotEncodeValue is an error that adds extra context to an encoder error.
+type ErrCannotEncodeValue struct {
+	Err       error
+	Parameter string
+	Field     string
+	Type      reflect.Type
+}
+
+// Error returns the full error message.
+func (e *ErrCannotEncodeValue) Error() string {
+	return fmt.Sprintf("cannot encode value for field %s (%s) into parameter %s: %s", e.Field, e.Type, e.Parameter, e.Err.Error())
+}
+
+// Unwrap returns the wrapped error.
+func (e *ErrCannotEncodeValue) Unwrap() error {
+	return e.Err
+}
+
+// ValueEncoder is a func used to encode a value into a query parameter string.
+type ValueEncoder func(value reflect.Value, delimiter string) (string, error)
+
+// Encode encodes the tagged fields of the given source struct into url.Values.
+// Values that encode to an empty string are omitted.
+// Slice fields using StyleExplode are encoded as repeated parameters.
+func (p *Parser) Encode(source interface{}) (url.Values, error) {
+	sourceValue := reflect.ValueOf(source)
+	if sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return nil, ErrNilSource
+		}
+		sourceValue = sourceValue.Elem()
+	}
+	if sourceValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v", ErrUnhandledFieldType, sourceValue.Type())
+	}
+
+	urlValues := url.Values{}
+	if err := p.encodeStruct("", sourceValue, urlValues); err != nil {
+		return nil, err
+	}
+	return urlValues, nil
+}
+
+// encodeStruct encodes each field in the given struct value.
+func (p *Parser) encodeStruct(prefix string, value reflect.Value, urlValues url.Values) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		if err := p.encodeField(prefix, valueType.Field(i), value.Field(i), urlValues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeField encodes the given field value and stores it in urlValues.
+func (p *Parser) EncodeField(field reflect.StructField, value reflect.Value, urlValues url.Values) error {
+	return p.encodeField("", field, value, urlValues)
+}
+
+// encodeField encodes the given field value and stores it in urlValues.
+// Nested and embedded structs and remain fields are handled in the same way as compileField.
+func (p *Parser) encodeField(prefix string, field reflect.StructField, value reflect.Value, urlValues url.Values) error {
+	tag, ok := field.Tag.Lookup(p.Tag)
+	if !ok {
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			return p.encodeStruct(prefix, value, urlValues)
+		}
+		if field.Anonymous && isStructPointer(field.Type) {
+			return checkEmbeddedPointer(field, p.Tag)
+		}
+		return nil
+	}
+	queryParameterName, options := parseTag(tag)
+	for _, option := range options {
+		switch option {
+		case TagOptionRemain:
+			if err := checkRemainField(field); err != nil {
+				return err
+			}
+			encodeRemain(value, urlValues)
+			return nil
+		default:
+			return fmt.Errorf("unknown tag option %q for field: %s: %w", option, field.Name, ErrInvalidTag)
+		}
+	}
+	if queryParameterName == "" {
+		return fmt.Errorf("missing tag value for field: %s: %w", field.Name, ErrInvalidTag)
+	}
+	queryParameterName = p.ParameterName(prefix, queryParameterName)
+
+	style, err := p.FieldStyle(field)
+	if err != nil {
+		return err
+	}
+
+	valuesEncoder, ok := p.valuesEncoder(field.Type, style)
+	if !ok {
+		if field.Type.Kind() == reflect.Struct {
+			return p.encodeStruct(queryParameterName, value, urlValues)
+		}
+		return fmt.Errorf("%w: %s: %v", ErrUnhandledFieldType, field.Name, field.Type.String())
+	}
+
+	encodedValues, err := valuesEncoder(value, p.FieldDelimiter(field))
+	if err != nil {
+		return &ErrCannotEncodeValue{
+			Err:       err,
+			Parameter: queryParameterName,
+			Type:      field.Type,
+			Field:     field.Name,
+		}
+	}
+	for _, encodedValue := range encodedValues {
+		urlValues.Add(queryParameterName, encodedValue)
+	}
+
+	return nil
+}
+
+// Encode encodes the tagged fields of the given source struct into url.Values.
+func Encode(source interface{}) (url.Values, error) {
+	return DefaultParser.Encode(source)
+}
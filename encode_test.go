@@ -0,0 +1,227 @@
+package queryparam_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ExampleEncode encodes a struct into url.Values.
+func ExampleEncode() {
+	requestData := struct {
+		Name      string    `queryparam:"name"`
+		DashNames []string  `queryparam:"dash-names" queryparamdelim:"-"`
+		Age       int       `queryparam:"age"`
+		CreatedAt time.Time `queryparam:"created-at"`
+		Ignored   string
+	}{
+		Name:      "Tom",
+		DashNames: []string{"Tom", "Jim", "Frank"},
+		Age:       123,
+		CreatedAt: time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC),
+		Ignored:   "not encoded",
+	}
+
+	urlValues, err := queryparam.Encode(requestData)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(urlValues.Encode())
+
+	// Output:
+	// age=123&created-at=2019-02-05T13%3A32%3A02Z&dash-names=Tom-Jim-Frank&name=Tom
+}
+
+type encodeRoundTrip struct {
+	Name      string             `queryparam:"name"`
+	Names     []string           `queryparam:"names"`
+	DashNames []string           `queryparam:"dash-names" queryparamdelim:"-"`
+	Age       int                `queryparam:"age"`
+	Age32     int32              `queryparam:"age32"`
+	Age64     int64              `queryparam:"age64"`
+	Float32   float32            `queryparam:"float32"`
+	Float64   float64            `queryparam:"float64"`
+	CreatedAt time.Time          `queryparam:"created-at"`
+	Bool      bool               `queryparam:"bool"`
+	Present   queryparam.Present `queryparam:"present"`
+}
+
+func TestEncode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tests := []encodeRoundTrip{
+		{
+			Names:     []string{},
+			DashNames: []string{},
+		},
+		{
+			Name:      "Tom",
+			Names:     []string{"Tom", "Jim"},
+			DashNames: []string{"Frank", "Bob"},
+			Age:       -123,
+			Age32:     123,
+			Age64:     1 << 40,
+			Float32:   123.45,
+			Float64:   -0.1,
+			CreatedAt: time.Date(2019, 2, 5, 13, 32, 2, 123456789, time.UTC),
+			Bool:      true,
+			Present:   true,
+		},
+	}
+
+	for i, exp := range tests {
+		exp := exp
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			urlValues, err := queryparam.Encode(&exp)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			var got encodeRoundTrip
+			if err := queryparam.Parse(urlValues, &got); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !reflect.DeepEqual(exp, got) {
+				t.Errorf("expected `%v`, got `%v`", exp, got)
+			}
+		})
+	}
+}
+
+func TestEncode_NestedStruct(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		Size int `queryparam:"size"`
+	}
+	type filter struct {
+		Status string `queryparam:"status"`
+	}
+	req := struct {
+		filter
+		Page page `queryparam:"page"`
+	}{
+		filter: filter{Status: "active"},
+		Page:   page{Size: 10},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"status":    []string{"active"},
+		"page.size": []string{"10"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
+
+func TestEncode_EmbeddedStructPointer(t *testing.T) {
+	t.Parallel()
+
+	type filter struct {
+		Status string `queryparam:"status"`
+	}
+	req := struct {
+		*filter
+	}{filter: &filter{Status: "active"}}
+
+	_, err := queryparam.Encode(req)
+	if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+		t.Errorf("expected ErrUnhandledFieldType, got %v", err)
+	}
+}
+
+func TestEncode_NilSource(t *testing.T) {
+	t.Parallel()
+
+	var req *struct{}
+
+	_, err := queryparam.Encode(req)
+	if exp, got := queryparam.ErrNilSource, err; exp != got {
+		t.Errorf("unexpected error. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestEncode_EmptyTag(t *testing.T) {
+	t.Parallel()
+
+	req := struct {
+		Name string `queryparam:""`
+	}{}
+
+	_, err := queryparam.Encode(req)
+	if !errors.Is(err, queryparam.ErrInvalidTag) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncode_UnhandledFieldType(t *testing.T) {
+	t.Parallel()
+
+	req := struct {
+		Age chan int `queryparam:"age"`
+	}{}
+
+	_, err := queryparam.Encode(req)
+	if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncode_ValueEncoderErrorReturned(t *testing.T) {
+	t.Parallel()
+
+	tmpErr := errors.New("something bad happened")
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		ValueEncoders: map[reflect.Type]queryparam.ValueEncoder{
+			reflect.TypeOf(""): func(value reflect.Value, delimiter string) (string, error) {
+				return "", tmpErr
+			},
+		},
+	}
+
+	req := struct {
+		Name string `queryparam:"name"`
+	}{}
+
+	_, err := p.Encode(req)
+	var encodeErr *queryparam.ErrCannotEncodeValue
+	if !errors.Is(err, tmpErr) || !errors.As(err, &encodeErr) || encodeErr.Parameter != "name" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncode_OmitsEmptyValues(t *testing.T) {
+	t.Parallel()
+
+	urlValues, err := queryparam.Encode(encodeRoundTrip{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"age":     []string{"0"},
+		"age32":   []string{"0"},
+		"age64":   []string{"0"},
+		"float32": []string{"0"},
+		"float64": []string{"0"},
+		"bool":    []string{"false"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
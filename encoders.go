@@ -0,0 +1,93 @@
+package queryparam
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultValueEncoders returns a set of default value encoders.
+// There is an encoder for every type handled by DefaultValueParsers.
+func DefaultValueEncoders() map[reflect.Type]ValueEncoder {
+	return map[reflect.Type]ValueEncoder{
+		reflect.TypeOf(""):             StringValueEncoder,
+		reflect.TypeOf([]string{}):     StringSliceValueEncoder,
+		reflect.TypeOf(0):              IntValueEncoder,
+		reflect.TypeOf(int32(0)):       IntValueEncoder,
+		reflect.TypeOf(int64(0)):       IntValueEncoder,
+		reflect.TypeOf(float32(0)):     FloatValueEncoder,
+		reflect.TypeOf(float64(0)):     FloatValueEncoder,
+		reflect.TypeOf(time.Time{}):    TimeValueEncoder,
+		reflect.TypeOf(false):          BoolValueEncoder,
+		reflect.TypeOf(Present(false)): PresentValueEncoder,
+	}
+}
+
+// valueEncoder returns the value encoder for the given type.
+// Types implementing Marshaler always use MarshalQueryParam. Otherwise the encoder registered
+// in ValueEncoders is used, falling back to MarshalText for types implementing
+// encoding.TextMarshaler, and then to the encoder for the element type of a slice.
+func (p *Parser) valueEncoder(t reflect.Type) (ValueEncoder, bool) {
+	if reflect.PtrTo(t).Implements(marshalerType) {
+		return marshalerValueEncoder, true
+	}
+	if valueEncoder, ok := p.ValueEncoders[t]; ok {
+		return valueEncoder, true
+	}
+	if reflect.PtrTo(t).Implements(textMarshalerType) {
+		return textMarshalerValueEncoder, true
+	}
+	if t.Kind() == reflect.Slice {
+		return p.sliceValueEncoder(t)
+	}
+	return nil, false
+}
+
+// StringValueEncoder encodes a string.
+func StringValueEncoder(value reflect.Value, _ string) (string, error) {
+	return value.String(), nil
+}
+
+// StringSliceValueEncoder encodes a []string by joining the items with the delimiter.
+func StringSliceValueEncoder(value reflect.Value, delimiter string) (string, error) {
+	items := make([]string, value.Len())
+	for i := range items {
+		items[i] = value.Index(i).String()
+	}
+	return strings.Join(items, delimiter), nil
+}
+
+// IntValueEncoder encodes any signed integer.
+func IntValueEncoder(value reflect.Value, _ string) (string, error) {
+	return strconv.FormatInt(value.Int(), 10), nil
+}
+
+// FloatValueEncoder encodes a float32 or float64.
+func FloatValueEncoder(value reflect.Value, _ string) (string, error) {
+	return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+}
+
+// TimeValueEncoder encodes a time.Time in RFC3339 format.
+// A zero time is encoded as an empty string.
+func TimeValueEncoder(value reflect.Value, _ string) (string, error) {
+	t := value.Interface().(time.Time)
+	if t.IsZero() {
+		return "", nil
+	}
+	return t.Format(time.RFC3339Nano), nil
+}
+
+// BoolValueEncoder encodes a bool.
+func BoolValueEncoder(value reflect.Value, _ string) (string, error) {
+	return strconv.FormatBool(value.Bool()), nil
+}
+
+// PresentValueEncoder encodes a Present.
+// A Present that is false is encoded as an empty string so that it is omitted.
+func PresentValueEncoder(value reflect.Value, _ string) (string, error) {
+	if !value.Bool() {
+		return "", nil
+	}
+	return "true", nil
+}
@@ -0,0 +1,66 @@
+package queryparam_test
+
+import (
+	"github.com/tomwright/queryparam/v4"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimeValueEncoder(t *testing.T) {
+	t.Run("Zero", func(t *testing.T) {
+		got, err := queryparam.TimeValueEncoder(reflect.ValueOf(time.Time{}), "")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if exp := ""; exp != got {
+			t.Errorf("expected res `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("Valid", func(t *testing.T) {
+		got, err := queryparam.TimeValueEncoder(reflect.ValueOf(time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC)), "")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if exp := "2019-02-05T13:32:02Z"; exp != got {
+			t.Errorf("expected res `%v`, got `%v`", exp, got)
+		}
+	})
+}
+
+func TestStringSliceValueEncoder(t *testing.T) {
+	got, err := queryparam.StringSliceValueEncoder(reflect.ValueOf([]string{"hello", "hi", "hey"}), "-")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+		return
+	}
+	if exp := "hello-hi-hey"; exp != got {
+		t.Errorf("expected res `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestFloatValueEncoder(t *testing.T) {
+	got, err := queryparam.FloatValueEncoder(reflect.ValueOf(float32(123.45)), "")
+	if err != nil {
+		t.Errorf("unexpected error: %s", err.Error())
+		return
+	}
+	if exp := "123.45"; exp != got {
+		t.Errorf("expected res `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestPresentValueEncoder(t *testing.T) {
+	for value, exp := range map[queryparam.Present]string{true: "true", false: ""} {
+		got, err := queryparam.PresentValueEncoder(reflect.ValueOf(value), "")
+		if err != nil {
+			t.Errorf("unexpected error: %s", err.Error())
+			return
+		}
+		if exp != got {
+			t.Errorf("expected res `%v`, got `%v`", exp, got)
+		}
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/tomwright/queryparam/v4
 
-go 1.13
+go 1.18
@@ -0,0 +1,106 @@
+package queryparam
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+// ErrorResponder is a func used to write an error response when a request cannot be bound.
+type ErrorResponder func(w http.ResponseWriter, r *http.Request, err error)
+
+// HandlerFunc is a func that handles a request along with its bound query parameters.
+type HandlerFunc[T any] func(w http.ResponseWriter, r *http.Request, params T)
+
+// BindRequest parses the parameters of the given request into the target, collecting every
+// parameter error in the same way as ParseAll.
+// Each field is read from the Sources it is tagged for, e.g.
+// `queryparam:"id" header:"X-Id"`, in order of precedence. The request body is only parsed if
+// a field is tagged for SourceForm.
+func (p *Parser) BindRequest(r *http.Request, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return ErrNonPointerTarget
+	}
+	plan, err := p.requestPlan(targetValue.Elem().Type())
+	if err != nil {
+		return err
+	}
+	urlValues := r.URL.Query()
+	lookup, err := requestLookup(r, urlValues, plan)
+	if err != nil {
+		return err
+	}
+	errs := &ErrInvalidParameters{}
+	if err := p.parsePlan(plan, urlValues, lookup, targetValue, errs); err != nil {
+		return err
+	}
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// BindRequest parses the parameters of the given request into the target using the
+// DefaultParser.
+func BindRequest(r *http.Request, target interface{}) error {
+	return DefaultParser.BindRequest(r, target)
+}
+
+// Handler returns a http.Handler that binds the query parameters of each request into a new T
+// using the DefaultParser before calling fn. If binding fails the error is written using
+// DefaultErrorResponder and fn is not called.
+func Handler[T any](fn HandlerFunc[T]) http.Handler {
+	return HandlerWith(DefaultParser, DefaultErrorResponder, fn)
+}
+
+// HandlerWith returns a http.Handler that binds the query parameters of each request into a new
+// T using the given Parser before calling fn. If binding fails the error is written using the
+// given ErrorResponder and fn is not called.
+// If the request context already contains a T, such as one stored by Middleware, it is used
+// rather than parsing the query parameters again.
+func HandlerWith[T any](p *Parser, responder ErrorResponder, fn HandlerFunc[T]) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if params, ok := FromContext[T](r.Context()); ok {
+			fn(w, r, params)
+			return
+		}
+		var params T
+		if err := p.BindRequest(r, &params); err != nil {
+			responder(w, r, err)
+			return
+		}
+		fn(w, r, params)
+	})
+}
+
+// ErrorStatusCode returns the HTTP status code that best describes the given error.
+// Errors caused by the request, such as an ErrInvalidParameterValue, return
+// http.StatusBadRequest. Errors caused by the target type, such as ErrInvalidTag or
+// ErrUnhandledFieldType, and any other errors return http.StatusInternalServerError.
+func ErrorStatusCode(err error) int {
+	if errors.Is(err, ErrInvalidTag) || errors.Is(err, ErrUnhandledFieldType) {
+		return http.StatusInternalServerError
+	}
+	var invalidParameterValue *ErrInvalidParameterValue
+	var validation *ErrValidation
+	var unknownParameter *ErrUnknownParameter
+	switch {
+	case errors.As(err, &invalidParameterValue), errors.As(err, &validation), errors.As(err, &unknownParameter):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// DefaultErrorResponder writes a plain text error response with the status code given by
+// ErrorStatusCode. The error message is only included for client errors, so that details of
+// the target type are not exposed.
+func DefaultErrorResponder(w http.ResponseWriter, _ *http.Request, err error) {
+	statusCode := ErrorStatusCode(err)
+	message := http.StatusText(statusCode)
+	if statusCode < http.StatusInternalServerError {
+		message = err.Error()
+	}
+	http.Error(w, message, statusCode)
+}
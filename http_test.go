@@ -0,0 +1,130 @@
+package queryparam_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tomwright/queryparam/v4"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type httpRequest struct {
+	Name  string `queryparam:"name"`
+	Limit int    `queryparam:"limit" max:"100"`
+}
+
+func TestBindRequest(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodGet, "/?name=tom&limit=10", nil)
+	req := &httpRequest{}
+	if err := queryparam.BindRequest(r, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp := (httpRequest{Name: "tom", Limit: 10}); exp != *req {
+		t.Errorf("expected `%v`, got `%v`", exp, *req)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := queryparam.Handler(func(w http.ResponseWriter, r *http.Request, params httpRequest) {
+		fmt.Fprintf(w, "%s %d", params.Name, params.Limit)
+	})
+
+	tests := []struct {
+		Name       string
+		URL        string
+		StatusCode int
+		Body       string
+	}{
+		{Name: "Valid", URL: "/?name=tom&limit=10", StatusCode: http.StatusOK, Body: "tom 10"},
+		{Name: "InvalidValue", URL: "/?limit=x", StatusCode: http.StatusBadRequest},
+		{Name: "Validation", URL: "/?limit=101", StatusCode: http.StatusBadRequest},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.URL, nil))
+			if exp, got := tc.StatusCode, w.Code; exp != got {
+				t.Errorf("unexpected status code. expected %d, got %d: %s", exp, got, w.Body.String())
+			}
+			if tc.Body != "" && tc.Body != w.Body.String() {
+				t.Errorf("unexpected body. expected `%s`, got `%s`", tc.Body, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerWith_ErrorResponder(t *testing.T) {
+	t.Parallel()
+
+	var gotErr error
+	responder := func(w http.ResponseWriter, r *http.Request, err error) {
+		gotErr = err
+		w.WriteHeader(http.StatusTeapot)
+	}
+	handler := queryparam.HandlerWith(queryparam.DefaultParser, responder, func(w http.ResponseWriter, r *http.Request, params httpRequest) {
+		t.Error("handler should not be called")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?limit=x", nil))
+	if exp, got := http.StatusTeapot, w.Code; exp != got {
+		t.Errorf("unexpected status code. expected %d, got %d", exp, got)
+	}
+	var paramErr *queryparam.ErrInvalidParameterValue
+	if !errors.As(gotErr, &paramErr) {
+		t.Errorf("unexpected error: %v", gotErr)
+	}
+}
+
+func TestHandler_InvalidTag(t *testing.T) {
+	t.Parallel()
+
+	type invalid struct {
+		Name string `queryparam:""`
+	}
+	handler := queryparam.Handler(func(w http.ResponseWriter, r *http.Request, params invalid) {
+		t.Error("handler should not be called")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if exp, got := http.StatusInternalServerError, w.Code; exp != got {
+		t.Errorf("unexpected status code. expected %d, got %d", exp, got)
+	}
+	if exp, got := http.StatusText(http.StatusInternalServerError)+"\n", w.Body.String(); exp != got {
+		t.Errorf("unexpected body. expected `%s`, got `%s`", exp, got)
+	}
+}
+
+func TestErrorStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		Err error
+		Exp int
+	}{
+		"InvalidParameterValue": {Err: &queryparam.ErrInvalidParameterValue{Err: errors.New("bad")}, Exp: http.StatusBadRequest},
+		"Validation":            {Err: &queryparam.ErrValidation{}, Exp: http.StatusBadRequest},
+		"UnknownParameter":      {Err: &queryparam.ErrUnknownParameter{Parameters: []string{"a"}}, Exp: http.StatusBadRequest},
+		"InvalidParameters": {Err: &queryparam.ErrInvalidParameters{Errors: []error{
+			&queryparam.ErrValidation{},
+		}}, Exp: http.StatusBadRequest},
+		"CannotSetValue": {Err: &queryparam.ErrCannotSetValue{Err: errors.New("bad")}, Exp: http.StatusInternalServerError},
+		"InvalidTag":     {Err: queryparam.ErrInvalidTag, Exp: http.StatusInternalServerError},
+		"Other":          {Err: errors.New("bad"), Exp: http.StatusInternalServerError},
+	}
+
+	for name, tc := range tests {
+		if got := queryparam.ErrorStatusCode(tc.Err); tc.Exp != got {
+			t.Errorf("%s: expected %d, got %d", name, tc.Exp, got)
+		}
+	}
+}
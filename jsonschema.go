@@ -0,0 +1,209 @@
+package queryparam
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the $schema of the documents returned by JSONSchema.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// DefaultValueSchemas returns a set of default value schemas.
+// There is a schema for every type handled by DefaultValueParsers.
+func DefaultValueSchemas() map[reflect.Type]json.RawMessage {
+	return map[reflect.Type]json.RawMessage{
+		reflect.TypeOf(""):             json.RawMessage(`{"type":"string"}`),
+		reflect.TypeOf([]string{}):     json.RawMessage(`{"type":"array","items":{"type":"string"}}`),
+		reflect.TypeOf(0):              json.RawMessage(`{"type":"integer"}`),
+		reflect.TypeOf(int32(0)):       json.RawMessage(`{"type":"integer","format":"int32","minimum":-2147483648,"maximum":2147483647}`),
+		reflect.TypeOf(int64(0)):       json.RawMessage(`{"type":"integer","format":"int64"}`),
+		reflect.TypeOf(float32(0)):     json.RawMessage(`{"type":"number","format":"float"}`),
+		reflect.TypeOf(float64(0)):     json.RawMessage(`{"type":"number","format":"double"}`),
+		reflect.TypeOf(time.Time{}):    json.RawMessage(`{"type":"string","format":"date-time"}`),
+		reflect.TypeOf(false):          json.RawMessage(`{"type":"boolean"}`),
+		reflect.TypeOf(Present(false)): json.RawMessage(`{"type":"boolean"}`),
+	}
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) object schema describing the query parameters
+// read into the given target, which may be a struct or a pointer to a struct.
+//
+// The schema of each parameter is taken from ValueSchemas, falling back to a schema based on
+// the kind of the type, and then to a string. Slices are described as arrays of their items.
+// Defaults, required and the other validation rules are described where JSON Schema supports
+// them. Unknown parameters are not allowed if Strict is set and the type has no remain field.
+func (p *Parser) JSONSchema(target interface{}) ([]byte, error) {
+	plan, err := p.targetPlan(target)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]interface{}, len(plan.fields))
+	required := make([]string, 0)
+	for _, info := range plan.describe() {
+		property, err := p.parameterSchema(info)
+		if err != nil {
+			return nil, err
+		}
+		properties[info.Name] = property
+		if info.Required {
+			required = append(required, info.Name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"$schema":    JSONSchemaDraft,
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if p.Strict && plan.remain == nil {
+		schema["additionalProperties"] = false
+	}
+	return json.Marshal(schema)
+}
+
+// JSONSchema returns a JSON Schema describing the query parameters read into the given target
+// using the DefaultParser.
+func JSONSchema(target interface{}) ([]byte, error) {
+	return DefaultParser.JSONSchema(target)
+}
+
+// ParameterSchema returns the JSON Schema of the given parameter, as used for its property in the
+// document returned by JSONSchema.
+func (p *Parser) ParameterSchema(info ParameterInfo) (json.RawMessage, error) {
+	schema, err := p.parameterSchema(info)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(schema)
+}
+
+// parameterSchema returns the schema of the given parameter.
+func (p *Parser) parameterSchema(info ParameterInfo) (map[string]interface{}, error) {
+	schema, err := p.valueSchema(info.Type, info.Slice)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema for field: %s: %w", info.Field.Name, err)
+	}
+
+	// item is the schema that rules are applied to, which is the items schema of a slice.
+	item := schema
+	if items, ok := schema["items"].(map[string]interface{}); ok && info.Slice {
+		item = items
+	}
+
+	if info.HasDefault {
+		if info.Slice {
+			values := []string{info.Default}
+			if info.Style != StyleExplode {
+				values = strings.Split(info.Default, info.Delimiter)
+			}
+			defaultValues := make([]interface{}, len(values))
+			for i, value := range values {
+				defaultValues[i] = schemaValue(item, value)
+			}
+			schema["default"] = defaultValues
+		} else {
+			schema["default"] = schemaValue(item, info.Default)
+		}
+	}
+
+	for _, rule := range info.Rules {
+		switch rule.Name {
+		case RuleMin, RuleMax:
+			if item["type"] != "integer" && item["type"] != "number" {
+				continue
+			}
+			keyword := "minimum"
+			if rule.Name == RuleMax {
+				keyword = "maximum"
+			}
+			item[keyword] = schemaValue(item, rule.Arg)
+		case RuleMinLen, RuleMaxLen:
+			limit, err := strconv.Atoi(rule.Arg)
+			if err != nil {
+				continue
+			}
+			switch {
+			case info.Slice && rule.Name == RuleMinLen:
+				schema["minItems"] = limit
+			case info.Slice:
+				schema["maxItems"] = limit
+			case rule.Name == RuleMinLen:
+				schema["minLength"] = limit
+			default:
+				schema["maxLength"] = limit
+			}
+		case RuleOneOf:
+			options := strings.Fields(rule.Arg)
+			enum := make([]interface{}, len(options))
+			for i, option := range options {
+				enum[i] = schemaValue(item, option)
+			}
+			item["enum"] = enum
+		case RulePattern:
+			item["pattern"] = rule.Arg
+		}
+	}
+
+	return schema, nil
+}
+
+// valueSchema returns a new schema for the given type.
+// If slice is true and no schema is registered for the type it is described as an array.
+func (p *Parser) valueSchema(t reflect.Type, slice bool) (map[string]interface{}, error) {
+	if fragment, ok := p.ValueSchemas[t]; ok {
+		schema := make(map[string]interface{})
+		if err := json.Unmarshal(fragment, &schema); err != nil {
+			return nil, fmt.Errorf("%v: %w", t, err)
+		}
+		return schema, nil
+	}
+
+	if slice {
+		items, err := p.valueSchema(valueType(t.Elem()), false)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	default:
+		return map[string]interface{}{"type": "string"}, nil
+	}
+}
+
+// schemaValue converts the given value to the JSON type of the schema, returning the value
+// as a string if it cannot be converted.
+func schemaValue(schema map[string]interface{}, value string) interface{} {
+	switch schema["type"] {
+	case "integer":
+		if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return v
+		}
+	case "number":
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			return v
+		}
+	case "boolean":
+		if v, err := strconv.ParseBool(value); err == nil {
+			return v
+		}
+	}
+	return value
+}
@@ -0,0 +1,127 @@
+package queryparam_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		Size int `queryparam:"size" default:"10" min:"1" max:"100"`
+	}
+	type request struct {
+		Name  string                       `queryparam:"name" required:"true" minlen:"1" pattern:"^[a-z]+$"`
+		IDs   []int64                      `queryparam:"id" maxlen:"5" min:"1"`
+		Tags  []string                     `queryparam:"tag" queryparamdelim:"|" default:"a|b"`
+		Sort  *string                      `queryparam:"sort" oneof:"asc desc"`
+		Score queryparam.Optional[float64] `queryparam:"score" oneof:"1.5 2.5"`
+		Since time.Time                    `queryparam:"since"`
+		Debug queryparam.Present           `queryparam:"debug"`
+		IP    net.IP                       `queryparam:"ip"`
+		Page  page                         `queryparam:"page"`
+	}
+
+	got, err := queryparam.JSONSchema(&request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string", "minLength": 1, "pattern": "^[a-z]+$"},
+			"id": {"type": "array", "items": {"type": "integer", "format": "int64", "minimum": 1}, "maxItems": 5},
+			"tag": {"type": "array", "items": {"type": "string"}, "default": ["a", "b"]},
+			"sort": {"type": "string", "enum": ["asc", "desc"]},
+			"score": {"type": "number", "format": "double", "enum": [1.5, 2.5]},
+			"since": {"type": "string", "format": "date-time"},
+			"debug": {"type": "boolean"},
+			"ip": {"type": "string"},
+			"page.size": {"type": "integer", "default": 10, "minimum": 1, "maximum": 100}
+		},
+		"required": ["name"]
+	}`
+	assertJSONEqual(t, exp, got)
+}
+
+func TestJSONSchema_CustomType(t *testing.T) {
+	t.Parallel()
+
+	type id string
+	type request struct {
+		ID  id   `queryparam:"id"`
+		IDs []id `queryparam:"ids" oneof:"a b"`
+	}
+
+	parser := &queryparam.Parser{
+		Tag:          "queryparam",
+		Delimiter:    ",",
+		Strict:       true,
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+		RuleTags:     queryparam.DefaultRuleTags(),
+		ValueSchemas: queryparam.DefaultValueSchemas(),
+	}
+	parser.ValueParsers[reflect.TypeOf(id(""))] = queryparam.StringValueParser
+	parser.ValueSchemas[reflect.TypeOf(id(""))] = json.RawMessage(`{"type":"string","format":"uuid"}`)
+
+	got, err := parser.JSONSchema(request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"ids": {"type": "array", "items": {"type": "string", "format": "uuid", "enum": ["a", "b"]}}
+		},
+		"additionalProperties": false
+	}`
+	assertJSONEqual(t, exp, got)
+}
+
+func TestJSONSchema_InvalidFragment(t *testing.T) {
+	t.Parallel()
+
+	parser := &queryparam.Parser{
+		Tag:          "queryparam",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+		ValueSchemas: map[reflect.Type]json.RawMessage{
+			reflect.TypeOf(""): json.RawMessage(`{`),
+		},
+	}
+	_, err := parser.JSONSchema(&struct {
+		Name string `queryparam:"name"`
+	}{})
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("expected a syntax error, got `%v`", err)
+	}
+}
+
+func assertJSONEqual(t *testing.T, exp string, got []byte) {
+	t.Helper()
+	var expValue, gotValue interface{}
+	if err := json.Unmarshal([]byte(exp), &expValue); err != nil {
+		t.Fatalf("invalid expected json: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if !reflect.DeepEqual(expValue, gotValue) {
+		t.Errorf("unexpected json.\nexpected: %s\ngot:      %s", exp, got)
+	}
+}
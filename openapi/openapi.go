@@ -0,0 +1,114 @@
+// Package openapi generates OpenAPI 3 parameter objects from structs tagged for use with
+// queryparam, so that API documentation stays in sync with the code that parses the request.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tomwright/queryparam/v4"
+)
+
+// ErrUnsupportedDelimiter is returned when a queryparam.StyleDelimited parameter uses a delimiter
+// that cannot be described by an OpenAPI style.
+var ErrUnsupportedDelimiter = errors.New("delimiter cannot be described by an openapi style")
+
+// Parameter is an OpenAPI 3 parameter object.
+type Parameter struct {
+	Name            string `json:"name"`
+	In              string `json:"in"`
+	Description     string `json:"description,omitempty"`
+	Required        bool   `json:"required,omitempty"`
+	AllowEmptyValue bool   `json:"allowEmptyValue,omitempty"`
+	Style           string `json:"style,omitempty"`
+	Explode         *bool  `json:"explode,omitempty"`
+	// Schema is the schema object of the parameter, as returned by
+	// queryparam.Parser.ParameterSchema.
+	Schema json.RawMessage `json:"schema"`
+}
+
+// DefaultGenerator is a default generator that uses the queryparam.DefaultParser.
+var DefaultGenerator = &Generator{
+	Parser: queryparam.DefaultParser,
+}
+
+// Generator is used to generate OpenAPI parameters.
+type Generator struct {
+	// Parser defines the tag rules used to find the parameters, and the schemas of their
+	// values in Parser.ValueSchemas.
+	Parser *queryparam.Parser
+}
+
+// Parameters returns an OpenAPI parameter object for each query parameter read into the given
+// target, which may be a struct or a pointer to a struct.
+//
+// Each schema is the same schema used for the parameter by queryparam.Parser.JSONSchema, so
+// custom types are described by registering a schema in Parser.ValueSchemas.
+// Slice parameters have their style and explode set from the field style and delimiter.
+// queryparam.StyleExplode and queryparam.StyleBoth are described as exploded form parameters.
+// queryparam.StyleDelimited is described as a spaceDelimited or pipeDelimited parameter if the
+// delimiter is a space or pipe, or as a form parameter that is not exploded if it is a comma.
+// Any other delimiter returns ErrUnsupportedDelimiter.
+func (g *Generator) Parameters(target interface{}) ([]Parameter, error) {
+	infos, err := g.Parser.Describe(target)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]Parameter, len(infos))
+	for i, info := range infos {
+		if res[i], err = g.parameter(info); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Parameters returns an OpenAPI parameter object for each query parameter read into the given
+// target using the DefaultGenerator.
+func Parameters(target interface{}) ([]Parameter, error) {
+	return DefaultGenerator.Parameters(target)
+}
+
+// parameter returns the parameter object for the given parameter.
+func (g *Generator) parameter(info queryparam.ParameterInfo) (Parameter, error) {
+	schema, err := g.Parser.ParameterSchema(info)
+	if err != nil {
+		return Parameter{}, err
+	}
+	parameter := Parameter{
+		Name:     info.Name,
+		In:       "query",
+		Required: info.Required,
+		Schema:   schema,
+	}
+	if info.Type == reflect.TypeOf(queryparam.Present(false)) {
+		parameter.AllowEmptyValue = true
+	}
+	if info.Slice {
+		style, explode, err := sliceStyle(info.Style, info.Delimiter)
+		if err != nil {
+			return Parameter{}, fmt.Errorf("%w: %q for parameter: %s", err, info.Delimiter, info.Name)
+		}
+		parameter.Style, parameter.Explode = style, &explode
+	}
+	return parameter, nil
+}
+
+// sliceStyle returns the OpenAPI style and explode values for the given style and delimiter.
+func sliceStyle(style queryparam.Style, delimiter string) (string, bool, error) {
+	if style != queryparam.StyleDelimited {
+		return "form", true, nil
+	}
+	switch delimiter {
+	case " ":
+		return "spaceDelimited", false, nil
+	case "|":
+		return "pipeDelimited", false, nil
+	case ",":
+		return "form", false, nil
+	default:
+		return "", false, ErrUnsupportedDelimiter
+	}
+}
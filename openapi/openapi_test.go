@@ -0,0 +1,153 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"github.com/tomwright/queryparam/v4/openapi"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParameters(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		Size int `queryparam:"size" default:"10" min:"1" max:"100"`
+	}
+	type request struct {
+		Name    string                       `queryparam:"name" required:"true" minlen:"1" pattern:"^[a-z]+$"`
+		IDs     []int64                      `queryparam:"id" maxlen:"5" min:"1"`
+		Tags    []string                     `queryparam:"tag" queryparamstyle:"delimited" queryparamdelim:"|" default:"a|b"`
+		Words   []string                     `queryparam:"word" queryparamstyle:"delimited" queryparamdelim:" "`
+		Sort    *string                      `queryparam:"sort" oneof:"asc desc"`
+		Score   queryparam.Optional[float64] `queryparam:"score" oneof:"1.5 2.5"`
+		Since   time.Time                    `queryparam:"since"`
+		Debug   queryparam.Present           `queryparam:"debug"`
+		IP      net.IP                       `queryparam:"ip"`
+		Page    page                         `queryparam:"page"`
+		Ignored string
+	}
+
+	parameters, err := openapi.Parameters(&request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	got, err := json.Marshal(parameters)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := `[
+		{"name":"name","in":"query","required":true,"schema":{"type":"string","minLength":1,"pattern":"^[a-z]+$"}},
+		{"name":"id","in":"query","style":"form","explode":true,"schema":{"type":"array","items":{"type":"integer","format":"int64","minimum":1},"maxItems":5}},
+		{"name":"tag","in":"query","style":"pipeDelimited","explode":false,"schema":{"type":"array","items":{"type":"string"},"default":["a","b"]}},
+		{"name":"word","in":"query","style":"spaceDelimited","explode":false,"schema":{"type":"array","items":{"type":"string"}}},
+		{"name":"sort","in":"query","schema":{"type":"string","enum":["asc","desc"]}},
+		{"name":"score","in":"query","schema":{"type":"number","format":"double","enum":[1.5,2.5]}},
+		{"name":"since","in":"query","schema":{"type":"string","format":"date-time"}},
+		{"name":"debug","in":"query","allowEmptyValue":true,"schema":{"type":"boolean"}},
+		{"name":"ip","in":"query","schema":{"type":"string"}},
+		{"name":"page.size","in":"query","schema":{"type":"integer","default":10,"minimum":1,"maximum":100}}
+	]`
+	assertJSONEqual(t, exp, got)
+}
+
+func TestGenerator_ValueSchemas(t *testing.T) {
+	t.Parallel()
+
+	type id string
+	type request struct {
+		ID    id   `queryparam:"id"`
+		IDs   []id `queryparam:"ids" oneof:"a b"`
+		Count int  `queryparam:"count" max:"5"`
+	}
+
+	parser := &queryparam.Parser{
+		Tag:          "queryparam",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+		ValueSchemas: queryparam.DefaultValueSchemas(),
+		RuleTags:     queryparam.DefaultRuleTags(),
+	}
+	parser.ValueParsers[reflect.TypeOf(id(""))] = queryparam.StringValueParser
+	parser.ValueSchemas[reflect.TypeOf(id(""))] = json.RawMessage(`{"type":"string","format":"uuid"}`)
+	parser.ValueSchemas[reflect.TypeOf(0)] = json.RawMessage(`{"type":"integer","minimum":1}`)
+	generator := &openapi.Generator{Parser: parser}
+
+	parameters, err := generator.Parameters(request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	got, err := json.Marshal(parameters)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := `[
+		{"name":"id","in":"query","schema":{"type":"string","format":"uuid"}},
+		{"name":"ids","in":"query","style":"form","explode":true,"schema":{"type":"array","items":{"type":"string","format":"uuid","enum":["a","b"]}}},
+		{"name":"count","in":"query","schema":{"type":"integer","minimum":1,"maximum":5}}
+	]`
+	assertJSONEqual(t, exp, got)
+
+	// each parameter schema must match the property of the JSON Schema document.
+	document, err := parser.JSONSchema(request{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	var jsonSchema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal(document, &jsonSchema); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	for _, parameter := range parameters {
+		assertJSONEqual(t, string(jsonSchema.Properties[parameter.Name]), parameter.Schema)
+	}
+}
+
+func TestParameters_InvalidTag(t *testing.T) {
+	t.Parallel()
+
+	_, err := openapi.Parameters(&struct {
+		Name string `queryparam:""`
+	}{})
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestParameters_UnsupportedDelimiter(t *testing.T) {
+	t.Parallel()
+
+	_, err := openapi.Parameters(&struct {
+		IDs []int `queryparam:"id" queryparamstyle:"delimited" queryparamdelim:"-"`
+	}{})
+	if !errors.Is(err, openapi.ErrUnsupportedDelimiter) {
+		t.Errorf("expected ErrUnsupportedDelimiter, got %v", err)
+	}
+}
+
+func assertJSONEqual(t *testing.T, exp string, got []byte) {
+	t.Helper()
+	var expValue, gotValue interface{}
+	if err := json.Unmarshal([]byte(exp), &expValue); err != nil {
+		t.Fatalf("invalid expected json: %v", err)
+	}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if !reflect.DeepEqual(expValue, gotValue) {
+		t.Errorf("unexpected json.\nexpected: %s\ngot:      %s", exp, got)
+	}
+}
@@ -0,0 +1,97 @@
+package queryparam
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Optional holds a value that may or may not have been given in a request.
+// It can be used with any type that has a value parser.
+//
+// Set is true if the parameter was present. Null is true if the parameter was present
+// with an empty value, e.g. ?name= or ?name, in which case Value is left as the zero value.
+//
+// When encoding, an Optional that is not Set is omitted and an Optional that is Null is
+// encoded as an empty value.
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// Get returns the value and whether it was set and not null.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Set && !o.Null
+}
+
+// optionalPkgPath is the package path of every instantiated Optional type.
+var optionalPkgPath = reflect.TypeOf(Optional[struct{}]{}).PkgPath()
+
+// The indexes of the Optional fields.
+const (
+	optionalValueIndex = 0
+	optionalSetIndex   = 1
+	optionalNullIndex  = 2
+)
+
+// isOptionalType returns true if the given type is an Optional.
+// Types are matched by their generic origin so that structs embedding an Optional, which
+// have a different layout, are not treated as one.
+func isOptionalType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.PkgPath() == optionalPkgPath && strings.HasPrefix(t.Name(), "Optional[")
+}
+
+// optionalValuesParser returns a valuesParser for an Optional type.
+// The value is parsed using the parser for the Optional's value type.
+func (p *Parser) optionalValuesParser(t reflect.Type, style Style) (valuesParser, bool) {
+	valueType := t.Field(optionalValueIndex).Type
+	valueParser, ok := p.valuesParser(valueType, style)
+	if !ok {
+		return nil, false
+	}
+	valueSetter, ok := p.valueSetter(valueType)
+	if !ok {
+		return nil, false
+	}
+	return func(values []string, delimiter string) (reflect.Value, string, error) {
+		result := reflect.New(t).Elem()
+		if values == nil {
+			return result, "", nil
+		}
+		result.Field(optionalSetIndex).SetBool(true)
+		if len(values) == 1 && values[0] == "" {
+			result.Field(optionalNullIndex).SetBool(true)
+			return result, "", nil
+		}
+		parsedValue, value, err := valueParser(values, delimiter)
+		if err != nil {
+			return parsedValue, value, err
+		}
+		if err := valueSetter(parsedValue, result.Field(optionalValueIndex)); err != nil {
+			return parsedValue, value, err
+		}
+		return result, value, nil
+	}, true
+}
+
+// optionalValuesEncoder returns a valuesEncoder for an Optional type.
+func (p *Parser) optionalValuesEncoder(t reflect.Type, style Style) (valuesEncoder, bool) {
+	valueEncoder, ok := p.valuesEncoder(t.Field(optionalValueIndex).Type, style)
+	if !ok {
+		return nil, false
+	}
+	return func(value reflect.Value, delimiter string) ([]string, error) {
+		if !value.Field(optionalSetIndex).Bool() {
+			return nil, nil
+		}
+		if value.Field(optionalNullIndex).Bool() {
+			return []string{""}, nil
+		}
+		encodedValues, err := valueEncoder(value.Field(optionalValueIndex), delimiter)
+		if err == nil && len(encodedValues) == 0 {
+			// the value is set so it must not be omitted.
+			encodedValues = []string{""}
+		}
+		return encodedValues, err
+	}, true
+}
@@ -0,0 +1,136 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type optionalRequest struct {
+	Name      queryparam.Optional[string]    `queryparam:"name"`
+	Limit     queryparam.Optional[int]       `queryparam:"limit" min:"1"`
+	Active    queryparam.Optional[bool]      `queryparam:"active"`
+	CreatedAt queryparam.Optional[time.Time] `queryparam:"created-at"`
+	IDs       queryparam.Optional[[]int]     `queryparam:"id"`
+}
+
+func TestParse_Optional(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Absent", func(t *testing.T) {
+		req := &optionalRequest{}
+		if err := queryparam.Parse(url.Values{}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !reflect.DeepEqual(&optionalRequest{}, req) {
+			t.Errorf("expected nothing to be set, got `%v`", req)
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		urlValues := url.Values{
+			"name":       []string{"tom"},
+			"limit":      []string{"10"},
+			"active":     []string{"false"},
+			"created-at": []string{"2019-02-05T13:32:02Z"},
+			"id":         []string{"1,2", "3"},
+		}
+		req := &optionalRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := &optionalRequest{
+			Name:      queryparam.Optional[string]{Value: "tom", Set: true},
+			Limit:     queryparam.Optional[int]{Value: 10, Set: true},
+			Active:    queryparam.Optional[bool]{Value: false, Set: true},
+			CreatedAt: queryparam.Optional[time.Time]{Value: time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC), Set: true},
+			IDs:       queryparam.Optional[[]int]{Value: []int{1, 2, 3}, Set: true},
+		}
+		if !reflect.DeepEqual(exp, req) {
+			t.Errorf("expected `%v`, got `%v`", exp, req)
+		}
+		if value, ok := req.Limit.Get(); !ok || value != 10 {
+			t.Errorf("unexpected get result: %v, %v", value, ok)
+		}
+	})
+	t.Run("Null", func(t *testing.T) {
+		urlValues, _ := url.ParseQuery("name=&limit")
+		req := &optionalRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := &optionalRequest{
+			Name:  queryparam.Optional[string]{Set: true, Null: true},
+			Limit: queryparam.Optional[int]{Set: true, Null: true},
+		}
+		if !reflect.DeepEqual(exp, req) {
+			t.Errorf("expected `%v`, got `%v`", exp, req)
+		}
+		if _, ok := req.Limit.Get(); ok {
+			t.Errorf("expected get to return false for a null value")
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		err := queryparam.Parse(url.Values{"limit": []string{"x"}}, &optionalRequest{})
+		var paramErr *queryparam.ErrInvalidParameterValue
+		if !errors.As(err, &paramErr) || paramErr.Parameter != "limit" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("Validation", func(t *testing.T) {
+		err := queryparam.Parse(url.Values{"limit": []string{"0"}}, &optionalRequest{})
+		var validationErr *queryparam.ErrValidation
+		if !errors.As(err, &validationErr) || validationErr.Rule != queryparam.RuleMin {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+type embeddedOptional struct {
+	queryparam.Optional[int]
+	Extra string
+}
+
+func TestParse_EmbeddedOptional(t *testing.T) {
+	t.Parallel()
+
+	// a struct that embeds an Optional is not an Optional, so it is parsed as a nested struct.
+	req := &struct {
+		X embeddedOptional `queryparam:"x"`
+	}{}
+	if err := queryparam.Parse(url.Values{"x": []string{"5"}}, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := (embeddedOptional{}), req.X; exp != got {
+		t.Errorf("expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestEncode_Optional(t *testing.T) {
+	t.Parallel()
+
+	req := optionalRequest{
+		Name:  queryparam.Optional[string]{Value: "tom", Set: true},
+		Limit: queryparam.Optional[int]{Value: 10},
+		IDs:   queryparam.Optional[[]int]{Set: true, Null: true},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"name": []string{"tom"},
+		"id":   []string{""},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
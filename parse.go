@@ -1,10 +1,13 @@
 package queryparam
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
+	"strings"
+	"sync/atomic"
 )
 
 var (
@@ -57,16 +60,110 @@ func (e *ErrCannotSetValue) Unwrap() error {
 	return e.Err
 }
 
+// ErrInvalidParameters is returned by ParseAll and contains every parameter error that
+// was found. Each error can be reached with errors.Is and errors.As.
+type ErrInvalidParameters struct {
+	Errors []error
+}
+
+// Error returns the full error message.
+func (e *ErrInvalidParameters) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d invalid parameters: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Is returns true if any of the contained errors match the target.
+func (e *ErrInvalidParameters) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first contained error that matches the target, and if so, sets target to that
+// error value and returns true.
+func (e *ErrInvalidParameters) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ByParameter returns the contained errors grouped by parameter name.
+func (e *ErrInvalidParameters) ByParameter() map[string][]error {
+	res := make(map[string][]error, len(e.Errors))
+	for _, err := range e.Errors {
+		var unknownParameter *ErrUnknownParameter
+		if errors.As(err, &unknownParameter) {
+			for _, parameter := range unknownParameter.Parameters {
+				res[parameter] = append(res[parameter], err)
+			}
+			continue
+		}
+		parameter := errorParameter(err)
+		res[parameter] = append(res[parameter], err)
+	}
+	return res
+}
+
+// add adds the given error if it is a parameter error, returning false if it is not.
+func (e *ErrInvalidParameters) add(err error) bool {
+	if errorParameter(err) == "" {
+		return false
+	}
+	e.Errors = append(e.Errors, err)
+	return true
+}
+
+// errorParameter returns the name of the parameter that caused the given error, or an
+// empty string if it is not a parameter error.
+func errorParameter(err error) string {
+	var invalidParameterValue *ErrInvalidParameterValue
+	if errors.As(err, &invalidParameterValue) {
+		return invalidParameterValue.Parameter
+	}
+	var cannotSetValue *ErrCannotSetValue
+	if errors.As(err, &cannotSetValue) {
+		return cannotSetValue.Parameter
+	}
+	var validation *ErrValidation
+	if errors.As(err, &validation) {
+		return validation.Parameter
+	}
+	var unknownParameter *ErrUnknownParameter
+	if errors.As(err, &unknownParameter) {
+		return unknownParameter.Parameters[0]
+	}
+	return ""
+}
+
 // Present allows you to determine whether or not a query parameter was present in a request.
+// A parameter is present if its key exists, even if it has no value, e.g. ?flag or ?flag=.
 type Present bool
 
 // DefaultParser is a default parser.
 var DefaultParser = &Parser{
-	Tag:          "queryparam",
-	DelimiterTag: "queryparamdelim",
-	Delimiter:    ",",
-	ValueParsers: DefaultValueParsers(),
-	ValueSetters: DefaultValueSetters(),
+	Tag:           "queryparam",
+	DelimiterTag:  "queryparamdelim",
+	Delimiter:     ",",
+	Separator:     ".",
+	StyleTag:      "queryparamstyle",
+	Style:         StyleBoth,
+	DefaultTag:    "default",
+	ValueParsers:  DefaultValueParsers(),
+	ValueSetters:  DefaultValueSetters(),
+	ValueEncoders: DefaultValueEncoders(),
+	ValueSchemas:  DefaultValueSchemas(),
+	RuleTags:      DefaultRuleTags(),
+	Sources:       DefaultSources(),
+	SourceTags:    DefaultSourceTags(),
 }
 
 // Parser is used to parse a URL.
@@ -77,12 +174,55 @@ type Parser struct {
 	DelimiterTag string
 	// Delimiter is the default string delimiter.
 	Delimiter string
+	// Separator is used to join the parameter name of a nested struct field with the
+	// parameter names of its own fields, e.g. page.size. An empty Separator is treated as ".".
+	Separator string
+	// StyleTag is the name of the struct tag where a slice style override is set.
+	StyleTag string
+	// Style is the default style used to read slice fields. An empty Style is
+	// treated as StyleBoth.
+	Style Style
+	// DefaultTag is the name of the struct tag where a default value is set. The default
+	// value is parsed in place of the parameter value when the parameter is absent.
+	DefaultTag string
+	// RuleTags is a map[string]string that defines the name of the struct tag where each
+	// validation rule is set, keyed by rule name, e.g. RuleMin. Rules without a tag name are
+	// not checked.
+	RuleTags map[string]string
+	// Strict causes an *ErrUnknownParameter to be returned if the query contains parameters
+	// that are not mapped to a field. It has no effect on types with a remain field.
+	// Parameters read by QueryUnmarshaler are not known to the parser, so Strict should not be
+	// used with types that rely on them.
+	Strict bool
+	// Merge causes fields to be left untouched when their parameter is absent, rather than
+	// being set to their default or zero value. This allows the query parameters to be
+	// overlaid onto a target that has already been populated. Remaining parameters are added
+	// to an existing remain map rather than replacing it.
+	Merge bool
 	// ValueParsers is a map[reflect.Type]ValueParser that defines how we parse query
 	// parameters based on the destination variable type.
 	ValueParsers map[reflect.Type]ValueParser
 	// ValueSetters is a map[reflect.Type]ValueSetter that defines how we set values
 	// onto target variables.
 	ValueSetters map[reflect.Type]ValueSetter
+	// ValueEncoders is a map[reflect.Type]ValueEncoder that defines how we encode
+	// values into query parameters.
+	ValueEncoders map[reflect.Type]ValueEncoder
+	// ValueSchemas is a map[reflect.Type]json.RawMessage that defines the JSON Schema fragment
+	// used to describe values of each type. Custom types can register their own fragment.
+	ValueSchemas map[reflect.Type]json.RawMessage
+	// Sources are the sources read by BindRequest, in order of precedence. A field tagged for
+	// more than one source is set from the first source in which its parameter is present.
+	// A nil Sources only reads SourceQuery. Parse and the other url.Values based methods always
+	// only read SourceQuery.
+	Sources []Source
+	// SourceTags is a map[Source]string that defines the name of the struct tag where the
+	// parameter name of each source is set. SourceQuery always uses Tag.
+	SourceTags map[Source]string
+
+	// cache holds the *planCache of compiled plans. It is held in an atomic.Value so that the
+	// Parser can still be copied, e.g. to customise the DefaultParser.
+	cache atomic.Value
 }
 
 // ValueParser is a func used to parse a value.
@@ -101,7 +241,30 @@ func (p *Parser) FieldDelimiter(field reflect.StructField) string {
 
 // Parse attempts to parse query parameters from the specified URL and store any found values
 // into the given target interface.
+// If the target implements QueryUnmarshaler, UnmarshalQuery is called after the tagged fields
+// have been parsed.
 func (p *Parser) Parse(urlValues url.Values, target interface{}) error {
+	return p.parse(urlValues, target, nil)
+}
+
+// ParseAll works in the same way as Parse, but rather than returning the first parameter
+// error it collects every ErrInvalidParameterValue, ErrCannotSetValue, ErrValidation and
+// ErrUnknownParameter into an *ErrInvalidParameters.
+// Other errors, such as ErrInvalidTag, are still returned immediately.
+func (p *Parser) ParseAll(urlValues url.Values, target interface{}) error {
+	errs := &ErrInvalidParameters{}
+	if err := p.parse(urlValues, target, errs); err != nil {
+		return err
+	}
+	if len(errs.Errors) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// parse parses the query parameters into the given target.
+// If errs is not nil any parameter errors are added to it rather than being returned.
+func (p *Parser) parse(urlValues url.Values, target interface{}, errs *ErrInvalidParameters) error {
 	if urlValues == nil {
 		return ErrInvalidURLValues
 	}
@@ -110,71 +273,61 @@ func (p *Parser) Parse(urlValues url.Values, target interface{}) error {
 		return ErrNonPointerTarget
 	}
 
-	targetElement := targetValue.Elem()
-	targetType := targetElement.Type()
-
-	for i := 0; i < targetType.NumField(); i++ {
-		if err := p.ParseField(targetType.Field(i), targetElement.Field(i), urlValues); err != nil {
-			return err
-		}
+	plan, err := p.typePlan(targetValue.Elem().Type())
+	if err != nil {
+		return err
 	}
-	return nil
+	return p.parsePlan(plan, urlValues, nil, targetValue, errs)
 }
 
-// ParseField parses the given field and sets the given value on the target.
-func (p *Parser) ParseField(field reflect.StructField, value reflect.Value, urlValues url.Values) error {
-	queryParameterName, ok := field.Tag.Lookup(p.Tag)
-	if !ok {
-		return nil
-	}
-	if queryParameterName == "" {
-		return fmt.Errorf("missing tag value for field: %s: %w", field.Name, ErrInvalidTag)
+// parsePlan parses the parameters into the given target pointer using a compiled type plan.
+// If lookup is nil only the query parameters are read. Strict, remain fields and
+// QueryUnmarshaler always use the query parameters.
+// If errs is not nil any parameter errors are added to it rather than being returned.
+func (p *Parser) parsePlan(plan *typePlan, urlValues url.Values, lookup valuesLookup, targetValue reflect.Value, errs *ErrInvalidParameters) error {
+	if p.Strict && plan.remain == nil {
+		if err := checkUnknownParameters(urlValues, plan.names); err != nil {
+			if errs == nil || !errs.add(err) {
+				return err
+			}
+		}
 	}
-	queryParameterValue := urlValues.Get(queryParameterName)
 
-	valueParser, ok := p.ValueParsers[field.Type]
-	if !ok {
-		return fmt.Errorf("%w: %s: %v", ErrUnhandledFieldType, field.Name, field.Type.String())
+	if err := plan.parse(targetValue.Elem(), urlValues, lookup, p.Merge, errs); err != nil {
+		return err
 	}
 
-	parsedValue, err := valueParser(queryParameterValue, p.FieldDelimiter(field))
-	if err != nil {
-		return &ErrInvalidParameterValue{
-			Err:       err,
-			Value:     queryParameterValue,
-			Parameter: queryParameterName,
-			Type:      field.Type,
-			Field:     field.Name,
+	if unmarshaler, ok := targetValue.Interface().(QueryUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalQuery(urlValues); err != nil {
+			if errs == nil || !errs.add(err) {
+				return err
+			}
 		}
 	}
+	return nil
+}
 
-	valueSetter, ok := p.ValueSetters[field.Type]
-	if !ok {
-		valueSetter, ok = p.ValueSetters[GenericType]
+// ParameterName returns the full parameter name for the given name, prefixed with
+// the parameter name of the parent struct if there is one.
+func (p *Parser) ParameterName(prefix string, name string) string {
+	if prefix == "" {
+		return name
 	}
-	if !ok {
-		return &ErrCannotSetValue{
-			Err:         ErrUnhandledFieldType,
-			Value:       queryParameterValue,
-			ParsedValue: parsedValue,
-			Parameter:   queryParameterName,
-			Type:        field.Type,
-			Field:       field.Name,
-		}
+	separator := p.Separator
+	if separator == "" {
+		separator = "."
 	}
+	return prefix + separator + name
+}
 
-	if err := valueSetter(parsedValue, value); err != nil {
-		return &ErrCannotSetValue{
-			Err:         err,
-			Value:       queryParameterValue,
-			ParsedValue: parsedValue,
-			Parameter:   queryParameterName,
-			Type:        field.Type,
-			Field:       field.Name,
-		}
+// ParseField parses the given field and sets the given value on the target.
+// The field plan is compiled and cached in the same way as the plans used by Parse.
+func (p *Parser) ParseField(field reflect.StructField, value reflect.Value, urlValues url.Values) error {
+	plan, err := p.fieldPlan(field)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return plan.parse(value, urlValues, nil, p.Merge, nil)
 }
 
 // Parse attempts to parse query parameters from the specified URL and store any found values
@@ -182,3 +335,9 @@ func (p *Parser) ParseField(field reflect.StructField, value reflect.Value, urlV
 func Parse(urlValues url.Values, target interface{}) error {
 	return DefaultParser.Parse(urlValues, target)
 }
+
+// ParseAll attempts to parse query parameters from the specified URL and store any found values
+// into the given target interface, collecting every parameter error.
+func ParseAll(urlValues url.Values, target interface{}) error {
+	return DefaultParser.ParseAll(urlValues, target)
+}
@@ -89,7 +89,7 @@ func ExampleParse() {
 	// bool false: false
 	// bool true: true
 	// bool empty: false
-	// present empty: false
+	// present empty: true
 	// present: true
 	// not present: false
 }
@@ -136,7 +136,7 @@ func TestParse_ParserUnhandledFieldType(t *testing.T) {
 	t.Parallel()
 
 	req := &struct {
-		Age struct{} `queryparam:"age"`
+		Age map[string]string `queryparam:"age"`
 	}{}
 
 	err := queryparam.Parse(urlValuesNameAge, req)
@@ -145,6 +145,307 @@ func TestParse_ParserUnhandledFieldType(t *testing.T) {
 	}
 }
 
+type parsePage struct {
+	Size   int `queryparam:"size"`
+	Number int `queryparam:"number"`
+}
+
+type parseFilter struct {
+	Status string `queryparam:"status"`
+}
+
+func TestParse_NestedStruct(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{
+		"page.size":   []string{"10"},
+		"page.number": []string{"2"},
+		"status":      []string{"active"},
+		"name":        []string{"tom"},
+	}
+
+	req := &struct {
+		parseFilter
+		Page parsePage `queryparam:"page"`
+		Name string    `queryparam:"name"`
+	}{}
+
+	if err := queryparam.Parse(urlValues, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := (parsePage{Size: 10, Number: 2}), req.Page; exp != got {
+		t.Errorf("unexpected page. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := "active", req.Status; exp != got {
+		t.Errorf("unexpected status. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := "tom", req.Name; exp != got {
+		t.Errorf("unexpected name. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_EmbeddedStructPointer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Tagged", func(t *testing.T) {
+		req := &struct {
+			*parseFilter
+			Name string `queryparam:"name"`
+		}{}
+		err := queryparam.Parse(url.Values{"status": []string{"active"}}, req)
+		if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+			t.Errorf("expected ErrUnhandledFieldType, got %v", err)
+		}
+	})
+	t.Run("Untagged", func(t *testing.T) {
+		req := &struct {
+			*url.URL
+			Name string `queryparam:"name"`
+		}{}
+		if err := queryparam.Parse(url.Values{"name": []string{"tom"}}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp, got := "tom", req.Name; exp != got {
+			t.Errorf("unexpected name. expected `%v`, got `%v`", exp, got)
+		}
+	})
+}
+
+func TestParse_NestedStructCustomSeparator(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		Separator:    "_",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	req := &struct {
+		Page struct {
+			Filter parseFilter `queryparam:"filter"`
+		} `queryparam:"page"`
+	}{}
+
+	if err := p.Parse(url.Values{"page_filter_status": []string{"active"}}, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := "active", req.Page.Filter.Status; exp != got {
+		t.Errorf("unexpected status. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_NestedStructEmptySeparator(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	req := &struct {
+		Page parsePage `queryparam:"page"`
+	}{}
+
+	if err := p.Parse(url.Values{"page.size": []string{"10"}, "page.number": []string{"2"}}, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := (parsePage{Size: 10, Number: 2}), req.Page; exp != got {
+		t.Errorf("unexpected page. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := "page.size", p.ParameterName("page", "size"); exp != got {
+		t.Errorf("unexpected parameter name. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParser_ParseField(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Name string    `queryparam:"name"`
+		Page parsePage `queryparam:"page"`
+	}{}
+
+	urlValues := url.Values{
+		"name":      []string{"tom"},
+		"page.size": []string{"10"},
+	}
+
+	reqValue := reflect.ValueOf(req).Elem()
+	for i := 0; i < reqValue.NumField(); i++ {
+		if err := queryparam.DefaultParser.ParseField(reqValue.Type().Field(i), reqValue.Field(i), urlValues); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	if exp, got := "tom", req.Name; exp != got {
+		t.Errorf("unexpected name. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := 10, req.Page.Size; exp != got {
+		t.Errorf("unexpected page size. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParser_ResetCache(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	req := &struct {
+		Name string `queryparam:"name"`
+	}{}
+
+	if err := p.Parse(urlValuesNameAge, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	p.ValueParsers[reflect.TypeOf("")] = func(value string, _ string) (reflect.Value, error) {
+		return reflect.ValueOf("custom " + value), nil
+	}
+	p.ResetCache()
+
+	if err := p.Parse(urlValuesNameAge, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := "custom tom", req.Name; exp != got {
+		t.Errorf("unexpected name. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_ConcurrentPlanCompilation(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		go func() {
+			req := &struct {
+				Name string `queryparam:"name"`
+				Age  int    `queryparam:"age"`
+			}{}
+			err := p.Parse(urlValuesNameAge, req)
+			if err == nil && (req.Name != "tom" || req.Age != 26) {
+				err = fmt.Errorf("unexpected result: %v", req)
+			}
+			errs <- err
+		}()
+	}
+	for i := 0; i < cap(errs); i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestParser_Copy(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Name string `queryparam:"name" qp:"n"`
+	}
+	urlValues := url.Values{"name": []string{"tom"}, "n": []string{"jim"}}
+
+	original := &queryparam.Parser{
+		Tag:          "queryparam",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+	// compile and cache the plan for the original before copying it.
+	req := &request{}
+	if err := original.Parse(urlValues, req); err != nil || req.Name != "tom" {
+		t.Errorf("unexpected result: %v: %v", req.Name, err)
+		return
+	}
+
+	copied := *original
+	copied.Tag = "qp"
+	req = &request{}
+	if err := copied.Parse(urlValues, req); err != nil || req.Name != "jim" {
+		t.Errorf("expected the copy not to use the cached plan of the original, got %v: %v", req.Name, err)
+	}
+
+	req = &request{}
+	if err := original.Parse(urlValues, req); err != nil || req.Name != "tom" {
+		t.Errorf("expected the original not to use the plan of the copy, got %v: %v", req.Name, err)
+	}
+}
+
+func TestParse_Merge(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		Separator:    ".",
+		DefaultTag:   "default",
+		Merge:        true,
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	type settings struct {
+		Limit  int       `queryparam:"limit" default:"25"`
+		Sort   []string  `queryparam:"sort"`
+		Active *bool     `queryparam:"active"`
+		Page   parsePage `queryparam:"page"`
+	}
+
+	active := true
+	req := &settings{
+		Limit:  50,
+		Sort:   []string{"name"},
+		Active: &active,
+		Page:   parsePage{Size: 10, Number: 3},
+	}
+
+	urlValues := url.Values{
+		"sort":      []string{"age"},
+		"page.size": []string{"20"},
+	}
+	if err := p.Parse(urlValues, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := &settings{
+		Limit:  50,
+		Sort:   []string{"age"},
+		Active: &active,
+		Page:   parsePage{Size: 20, Number: 3},
+	}
+	if !reflect.DeepEqual(exp, req) {
+		t.Errorf("expected `%v`, got `%v`", exp, req)
+	}
+}
+
 func TestParse_SetterUnhandledFieldType(t *testing.T) {
 	t.Parallel()
 
@@ -250,6 +551,53 @@ func BenchmarkParse(b *testing.B) {
 	b.ReportAllocs()
 }
 
+type benchmarkRequest struct {
+	Name         string    `queryparam:"name"`
+	NameList     []string  `queryparam:"name-list"`
+	NameListDash []string  `queryparam:"name-list" queryparamdelim:"-"`
+	Age          int       `queryparam:"age"`
+	IDs          []int     `queryparam:"id"`
+	Limit        *int      `queryparam:"limit"`
+	Page         parsePage `queryparam:"page"`
+}
+
+var urlValuesBenchmark = url.Values{
+	"name":        []string{"tom"},
+	"name-list":   []string{"tom,jim"},
+	"age":         []string{"26"},
+	"id":          []string{"1,2", "3"},
+	"limit":       []string{"10"},
+	"page.size":   []string{"10"},
+	"page.number": []string{"2"},
+}
+
+// BenchmarkParse_CachedPlan parses benchmarkRequest using its cached type plan.
+func BenchmarkParse_CachedPlan(b *testing.B) {
+	p := *queryparam.DefaultParser
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var data benchmarkRequest
+		if err := p.Parse(urlValuesBenchmark, &data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkParse_UncachedPlan parses benchmarkRequest with the same input as
+// BenchmarkParse_CachedPlan, but resets the cache before each parse so that the tags are read and
+// the parsers and setters looked up on every call, as they were before type plans were cached.
+func BenchmarkParse_UncachedPlan(b *testing.B) {
+	p := *queryparam.DefaultParser
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.ResetCache()
+		var data benchmarkRequest
+		if err := p.Parse(urlValuesBenchmark, &data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestErrInvalidParameterValue_Unwrap(t *testing.T) {
 	tmpErr := errors.New("something bad")
 	e := &queryparam.ErrInvalidParameterValue{
@@ -286,3 +634,75 @@ func TestCannotSetValue_Unwrap(t *testing.T) {
 		t.Error("expected is to return true")
 	}
 }
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{
+		"name":   []string{"tom"},
+		"age":    []string{"x"},
+		"active": []string{"maybe"},
+		"ids":    []string{"1,y"},
+	}
+
+	req := &struct {
+		Name   string `queryparam:"name"`
+		Age    int    `queryparam:"age"`
+		Active bool   `queryparam:"active"`
+		IDs    []int  `queryparam:"ids"`
+	}{}
+
+	err := queryparam.ParseAll(urlValues, req)
+
+	var errs *queryparam.ErrInvalidParameters
+	if !errors.As(err, &errs) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := 3, len(errs.Errors); exp != got {
+		t.Errorf("expected %d errors, got %d: %v", exp, got, err)
+	}
+	byParameter := errs.ByParameter()
+	for _, parameter := range []string{"age", "active", "ids"} {
+		if len(byParameter[parameter]) != 1 {
+			t.Errorf("expected 1 error for parameter %s, got %v", parameter, byParameter[parameter])
+		}
+	}
+	if !errors.Is(err, queryparam.ErrInvalidBoolValue) {
+		t.Errorf("expected is to return true")
+	}
+	var paramErr *queryparam.ErrInvalidParameterValue
+	if !errors.As(err, &paramErr) || paramErr.Parameter != "age" {
+		t.Errorf("expected as to return the first parameter error, got %v", paramErr)
+	}
+	if exp, got := "tom", req.Name; exp != got {
+		t.Errorf("unexpected name. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParseAll_NoErrors(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Name string `queryparam:"name"`
+	}{}
+
+	if err := queryparam.ParseAll(urlValuesNameAge, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseAll_InvalidTagReturnedImmediately(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Age  int    `queryparam:"age"`
+		Name string `queryparam:""`
+	}{}
+
+	err := queryparam.ParseAll(url.Values{"age": []string{"x"}}, req)
+	var errs *queryparam.ErrInvalidParameters
+	if !errors.Is(err, queryparam.ErrInvalidTag) || errors.As(err, &errs) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
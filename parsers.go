@@ -27,6 +27,26 @@ func DefaultValueParsers() map[reflect.Type]ValueParser {
 	}
 }
 
+// valueParser returns the value parser for the given type.
+// Types implementing Unmarshaler always use UnmarshalQueryParam. Otherwise the parser registered
+// in ValueParsers is used, falling back to UnmarshalText for types implementing
+// encoding.TextUnmarshaler, and then to the parser for the element type of a slice.
+func (p *Parser) valueParser(t reflect.Type) (ValueParser, bool) {
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return unmarshalerValueParser(t), true
+	}
+	if valueParser, ok := p.ValueParsers[t]; ok {
+		return valueParser, true
+	}
+	if reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return textUnmarshalerValueParser(t), true
+	}
+	if t.Kind() == reflect.Slice {
+		return p.sliceValueParser(t)
+	}
+	return nil, false
+}
+
 // StringValueParser parses a string into a string.
 func StringValueParser(value string, _ string) (reflect.Value, error) {
 	return reflect.ValueOf(value), nil
@@ -113,8 +133,9 @@ func BoolValueParser(value string, _ string) (reflect.Value, error) {
 	}
 }
 
-// PresentValueParser sets the target to true.
-// This parser will only be executed if the parameter is present.
+// PresentValueParser sets the target to true if the value is not empty.
+// When parsing struct fields the Parser determines whether a Present is true by checking
+// whether the parameter key exists instead, so this parser is not used.
 func PresentValueParser(value string, _ string) (reflect.Value, error) {
 	return reflect.ValueOf(Present(value != "")), nil
 }
@@ -0,0 +1,429 @@
+package queryparam
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sync"
+)
+
+// typePlan is the compiled plan used to parse query parameters into a struct type.
+type typePlan struct {
+	// sources are the sources read by the plan, in order of precedence.
+	sources []Source
+	fields  []*fieldPlan
+	// names is the set of query parameter names used by the fields.
+	names map[string]struct{}
+	// remain is the index sequence of the remain field, or nil if there is none.
+	remain []int
+}
+
+// fieldPlan is the compiled plan used to parse a single query parameter into a struct field.
+type fieldPlan struct {
+	// index is the index sequence of the field within the root struct, as used by
+	// reflect.Value.FieldByIndex. An empty index refers to the root value itself.
+	index []int
+	field reflect.StructField
+	// name is the parameter name within the source of highest precedence.
+	name string
+	// sources are the parameter names of the field within each source, in order of precedence.
+	sources   []fieldSource
+	delimiter string
+	style     Style
+	parser    valuesParser
+	// defaultValues are used in place of the parameter values when the parameter is absent.
+	// It is nil if the field has no default.
+	defaultValues []string
+	// required is true if the parameter must be present.
+	required bool
+	// rules are the validation rules checked after the value has been set.
+	rules []validationRule
+	// setter is nil if no value setter could be found for the field type.
+	setter ValueSetter
+}
+
+// ResetCache clears any compiled type plans.
+// Tag names, delimiters, separators, styles, value parsers and setters, rules and sources are all
+// compiled into the plans, so it must be called if any field of the Parser is modified after the
+// parser has been used.
+func (p *Parser) ResetCache() {
+	p.cache.Store(&planCache{parser: p})
+}
+
+// planCache is a cache of the compiled plans of a Parser.
+type planCache struct {
+	// parser is the Parser that owns the cache. A copy of the Parser has a different address,
+	// so it does not use the plans compiled for the original.
+	parser *Parser
+	// plans is a cache of compiled type plans, keyed by reflect.Type.
+	plans sync.Map
+	// fieldPlans is a cache of compiled plans used by ParseField, keyed by fieldKey.
+	fieldPlans sync.Map
+	// requestPlans is a cache of compiled type plans used by BindRequest, keyed by reflect.Type.
+	requestPlans sync.Map
+}
+
+// planCache returns the plan cache of the parser, creating it if needed.
+func (p *Parser) planCache() *planCache {
+	for {
+		current, _ := p.cache.Load().(*planCache)
+		if current != nil && current.parser == p {
+			return current
+		}
+		var old interface{}
+		if current != nil {
+			old = current
+		}
+		// the cache may have been replaced by another goroutine, so it is loaded again either way.
+		p.cache.CompareAndSwap(old, &planCache{parser: p})
+	}
+}
+
+// typePlan returns the plan used to parse query parameters into the given struct type,
+// compiling and caching it if needed.
+func (p *Parser) typePlan(t reflect.Type) (*typePlan, error) {
+	return p.cachedPlan(&p.planCache().plans, querySources, t)
+}
+
+// fieldKey identifies a struct field independently of its position in the struct.
+type fieldKey struct {
+	name      string
+	pkgPath   string
+	t         reflect.Type
+	tag       reflect.StructTag
+	anonymous bool
+}
+
+// fieldPlan returns the plan used to parse query parameters into the given field, compiling
+// and caching it if needed.
+func (p *Parser) fieldPlan(field reflect.StructField) (*typePlan, error) {
+	key := fieldKey{name: field.Name, pkgPath: field.PkgPath, t: field.Type, tag: field.Tag, anonymous: field.Anonymous}
+	fieldPlans := &p.planCache().fieldPlans
+	if plan, ok := fieldPlans.Load(key); ok {
+		return plan.(*typePlan), nil
+	}
+	// clear the index so that the compiled index sequences are relative to the field value.
+	field.Index = nil
+	plan := &typePlan{sources: querySources, names: make(map[string]struct{})}
+	if err := p.compileField(plan, rootPrefixes(plan.sources), nil, field); err != nil {
+		return nil, err
+	}
+	actual, _ := fieldPlans.LoadOrStore(key, plan)
+	return actual.(*typePlan), nil
+}
+
+// requestPlan returns the plan used to bind requests into the given struct type, compiling and
+// caching it if needed.
+func (p *Parser) requestPlan(t reflect.Type) (*typePlan, error) {
+	sources := p.Sources
+	if sources == nil {
+		sources = querySources
+	}
+	return p.cachedPlan(&p.planCache().requestPlans, sources, t)
+}
+
+// cachedPlan returns the plan for the given struct type from the given cache, compiling and
+// caching it if needed.
+func (p *Parser) cachedPlan(plans *sync.Map, sources []Source, t reflect.Type) (*typePlan, error) {
+	if plan, ok := plans.Load(t); ok {
+		return plan.(*typePlan), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %v", ErrUnhandledFieldType, t)
+	}
+	plan := &typePlan{sources: sources, names: make(map[string]struct{})}
+	if err := p.compileStruct(plan, rootPrefixes(sources), nil, t); err != nil {
+		return nil, err
+	}
+	actual, _ := plans.LoadOrStore(t, plan)
+	return actual.(*typePlan), nil
+}
+
+// reads returns true if any field in the plan reads from the given source.
+func (plan *typePlan) reads(source Source) bool {
+	for _, fieldPlan := range plan.fields {
+		for _, fieldSource := range fieldPlan.sources {
+			if fieldSource.source == source {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rootPrefixes returns the prefixes of the fields of a root struct, which can be read from every
+// one of the given sources.
+func rootPrefixes(sources []Source) map[Source]string {
+	prefixes := make(map[Source]string, len(sources))
+	for _, source := range sources {
+		prefixes[source] = ""
+	}
+	return prefixes
+}
+
+// isStructPointer returns true if the given type is a pointer to a struct.
+func isStructPointer(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
+}
+
+// checkEmbeddedPointer returns an error if the given embedded struct pointer field contains any
+// fields tagged with the given tag names. Embedded struct pointers are not allocated, so their
+// tagged fields cannot be read or written.
+func checkEmbeddedPointer(field reflect.StructField, tagNames ...string) error {
+	if hasTaggedField(field.Type.Elem(), tagNames, map[reflect.Type]bool{}) {
+		return fmt.Errorf("%w: %s: embedded struct pointers are not supported: %v", ErrUnhandledFieldType, field.Name, field.Type.String())
+	}
+	return nil
+}
+
+// hasTaggedField returns true if any field in the given struct type, or in a struct embedded
+// in it, is tagged with one of the given tag names.
+func hasTaggedField(t reflect.Type, tagNames []string, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		for _, tagName := range tagNames {
+			if tagName == "" {
+				continue
+			}
+			if _, ok := field.Tag.Lookup(tagName); ok {
+				return true
+			}
+		}
+		if !field.Anonymous {
+			continue
+		}
+		embedded := field.Type
+		if isStructPointer(embedded) {
+			embedded = embedded.Elem()
+		}
+		if embedded.Kind() == reflect.Struct && hasTaggedField(embedded, tagNames, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileStruct compiles a plan for each field in the given struct type.
+// prefixes holds the parameter name prefix of each source that the fields can be read from.
+func (p *Parser) compileStruct(plan *typePlan, prefixes map[Source]string, index []int, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		if err := p.compileField(plan, prefixes, index, t.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// compileField compiles a plan for the given field and adds it to the type plan.
+// Struct fields without a value parser are compiled recursively, with the parameter
+// names of their fields prefixed with the parameter name of the struct field.
+// Embedded structs without a tag have their fields promoted. Embedded struct pointers are not
+// supported and return ErrUnhandledFieldType if they contain tagged fields.
+// A field may be tagged for any of the plan sources. The fields of a nested struct are prefixed
+// with the name of the struct field within each source, and can only be read from the sources
+// that the struct field is tagged for.
+func (p *Parser) compileField(plan *typePlan, prefixes map[Source]string, index []int, field reflect.StructField) error {
+	fieldIndex := make([]int, len(index), len(index)+len(field.Index))
+	copy(fieldIndex, index)
+	fieldIndex = append(fieldIndex, field.Index...)
+
+	var sources []fieldSource
+	for _, source := range plan.sources {
+		prefix, ok := prefixes[source]
+		if !ok {
+			continue
+		}
+		tagName := p.sourceTag(source)
+		if tagName == "" {
+			continue
+		}
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok {
+			continue
+		}
+		name, options := parseTag(tag)
+		for _, option := range options {
+			switch {
+			case option == TagOptionRemain && source == SourceQuery:
+				return p.compileRemain(plan, fieldIndex, field)
+			default:
+				return fmt.Errorf("unknown tag option %q for field: %s: %w", option, field.Name, ErrInvalidTag)
+			}
+		}
+		if name == "" {
+			return fmt.Errorf("missing tag value for field: %s: %w", field.Name, ErrInvalidTag)
+		}
+		sources = append(sources, fieldSource{source: source, name: p.ParameterName(prefix, name)})
+	}
+	if len(sources) == 0 {
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			return p.compileStruct(plan, prefixes, fieldIndex, field.Type)
+		}
+		if field.Anonymous && isStructPointer(field.Type) {
+			tagNames := make([]string, 0, len(prefixes))
+			for source := range prefixes {
+				tagNames = append(tagNames, p.sourceTag(source))
+			}
+			return checkEmbeddedPointer(field, tagNames...)
+		}
+		return nil
+	}
+	queryParameterName := sources[0].name
+
+	style, err := p.FieldStyle(field)
+	if err != nil {
+		return err
+	}
+
+	valuesParser, ok := p.valuesParser(field.Type, style)
+	if !ok {
+		if field.Type.Kind() == reflect.Struct {
+			nestedPrefixes := make(map[Source]string, len(sources))
+			for _, fieldSource := range sources {
+				nestedPrefixes[fieldSource.source] = fieldSource.name
+			}
+			return p.compileStruct(plan, nestedPrefixes, fieldIndex, field.Type)
+		}
+		return fmt.Errorf("%w: %s: %v", ErrUnhandledFieldType, field.Name, field.Type.String())
+	}
+
+	delimiter := p.FieldDelimiter(field)
+
+	var defaultValues []string
+	if defaultValue, ok := field.Tag.Lookup(p.DefaultTag); ok && p.DefaultTag != "" {
+		defaultValues = []string{defaultValue}
+		if _, _, err := valuesParser(defaultValues, delimiter); err != nil {
+			return fmt.Errorf("invalid default value %q for field: %s: %v: %w", defaultValue, field.Name, err, ErrInvalidTag)
+		}
+	}
+
+	required, rules, err := p.compileValidationRules(field)
+	if err != nil {
+		return err
+	}
+	if required && defaultValues != nil {
+		return fmt.Errorf("required field cannot have a default value: %s: %w", field.Name, ErrInvalidTag)
+	}
+
+	valueSetter, _ := p.valueSetter(field.Type)
+
+	for _, fieldSource := range sources {
+		if fieldSource.source == SourceQuery {
+			plan.names[fieldSource.name] = struct{}{}
+		}
+	}
+	plan.fields = append(plan.fields, &fieldPlan{
+		index:         fieldIndex,
+		field:         field,
+		name:          queryParameterName,
+		sources:       sources,
+		delimiter:     delimiter,
+		style:         style,
+		parser:        valuesParser,
+		defaultValues: defaultValues,
+		required:      required,
+		rules:         rules,
+		setter:        valueSetter,
+	})
+	return nil
+}
+
+// parse parses the parameters into the given struct value.
+// If lookup is nil each field is read from urlValues using its name, otherwise from the values
+// returned by lookup. Any remain field is populated from urlValues.
+// If errs is not nil any parameter errors are added to it rather than being returned.
+// If merge is true fields are left untouched when their parameter is absent.
+func (plan *typePlan) parse(value reflect.Value, urlValues url.Values, lookup valuesLookup, merge bool, errs *ErrInvalidParameters) error {
+	for _, fieldPlan := range plan.fields {
+		fieldValue := value
+		if len(fieldPlan.index) > 0 {
+			fieldValue = value.FieldByIndex(fieldPlan.index)
+		}
+		if err := fieldPlan.parse(fieldValue, urlValues, lookup, merge); err != nil {
+			if errs == nil || !errs.add(err) {
+				return err
+			}
+		}
+	}
+	if plan.remain != nil {
+		remainValue := value
+		if len(plan.remain) > 0 {
+			remainValue = value.FieldByIndex(plan.remain)
+		}
+		plan.parseRemain(remainValue, urlValues, merge)
+	}
+	return nil
+}
+
+// parse parses the parameter and sets the parsed value on the given field value.
+// The values are read from the first source, in order of precedence, in which the parameter is
+// present. The value is validated if the parameter is present or a default value is used.
+// If merge is true the field value is left untouched when the parameter is absent.
+func (fieldPlan *fieldPlan) parse(value reflect.Value, urlValues url.Values, lookup valuesLookup, merge bool) error {
+	name := fieldPlan.name
+	var values []string
+	var ok bool
+	if lookup == nil {
+		values, ok = urlValues[name]
+	} else {
+		for _, fieldSource := range fieldPlan.sources {
+			if values, ok = lookup(fieldSource.source, fieldSource.name); ok {
+				name = fieldSource.name
+				break
+			}
+		}
+	}
+	if !ok && fieldPlan.required {
+		return fieldPlan.validationError(name, RuleRequired, "true", "")
+	}
+	if !ok && merge {
+		return nil
+	}
+	if !ok && fieldPlan.defaultValues != nil {
+		ok = true
+		values = fieldPlan.defaultValues
+	}
+
+	parsedValue, queryParameterValue, err := fieldPlan.parser(values, fieldPlan.delimiter)
+	if err != nil {
+		return &ErrInvalidParameterValue{
+			Err:       err,
+			Value:     queryParameterValue,
+			Parameter: name,
+			Type:      fieldPlan.field.Type,
+			Field:     fieldPlan.field.Name,
+		}
+	}
+
+	if fieldPlan.setter == nil {
+		return &ErrCannotSetValue{
+			Err:         ErrUnhandledFieldType,
+			Value:       queryParameterValue,
+			ParsedValue: parsedValue,
+			Parameter:   name,
+			Type:        fieldPlan.field.Type,
+			Field:       fieldPlan.field.Name,
+		}
+	}
+
+	if err := fieldPlan.setter(parsedValue, value); err != nil {
+		return &ErrCannotSetValue{
+			Err:         err,
+			Value:       queryParameterValue,
+			ParsedValue: parsedValue,
+			Parameter:   name,
+			Type:        fieldPlan.field.Type,
+			Field:       fieldPlan.field.Name,
+		}
+	}
+
+	if ok {
+		return fieldPlan.validate(value, name, queryParameterValue)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type defaultRequest struct {
+	Limit     int       `queryparam:"limit" default:"25"`
+	Sort      []string  `queryparam:"sort" default:"name,-age"`
+	Active    *bool     `queryparam:"active" default:"true"`
+	CreatedAt time.Time `queryparam:"created-at" default:"2019-02-05T13:32:02Z"`
+	Name      string    `queryparam:"name"`
+}
+
+func TestParse_Default(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Absent", func(t *testing.T) {
+		req := &defaultRequest{}
+		if err := queryparam.Parse(url.Values{}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp, got := 25, req.Limit; exp != got {
+			t.Errorf("unexpected limit. expected `%v`, got `%v`", exp, got)
+		}
+		if exp, got := []string{"name", "-age"}, req.Sort; !reflect.DeepEqual(exp, got) {
+			t.Errorf("unexpected sort. expected `%v`, got `%v`", exp, got)
+		}
+		if req.Active == nil || !*req.Active {
+			t.Errorf("unexpected active: %v", req.Active)
+		}
+		if exp, got := time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC), req.CreatedAt; !exp.Equal(got) {
+			t.Errorf("unexpected created at. expected `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("Present", func(t *testing.T) {
+		urlValues := url.Values{
+			"limit":  []string{"10"},
+			"sort":   []string{""},
+			"active": []string{"false"},
+		}
+		req := &defaultRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp, got := 10, req.Limit; exp != got {
+			t.Errorf("unexpected limit. expected `%v`, got `%v`", exp, got)
+		}
+		if exp, got := []string{}, req.Sort; !reflect.DeepEqual(exp, got) {
+			t.Errorf("unexpected sort. expected `%v`, got `%v`", exp, got)
+		}
+		if req.Active == nil || *req.Active {
+			t.Errorf("unexpected active: %v", req.Active)
+		}
+	})
+	t.Run("DefaultsAreNotShared", func(t *testing.T) {
+		first := &defaultRequest{}
+		if err := queryparam.Parse(url.Values{}, first); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		first.Sort[0] = "changed"
+		second := &defaultRequest{}
+		if err := queryparam.Parse(url.Values{}, second); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp, got := "name", second.Sort[0]; exp != got {
+			t.Errorf("unexpected sort. expected `%v`, got `%v`", exp, got)
+		}
+	})
+}
+
+func TestParse_InvalidDefault(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Name  string `queryparam:"name"`
+		Limit int    `queryparam:"limit" default:"lots"`
+	}{}
+
+	// the default is checked when the plan is compiled, even though the limit is present.
+	err := queryparam.Parse(url.Values{"limit": []string{"10"}}, req)
+	if !errors.Is(err, queryparam.ErrInvalidTag) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package queryparam
+
+import (
+	"reflect"
+)
+
+// pointerValuesParser returns a valuesParser for a pointer type that has no registered parser.
+// The pointer is left nil when the parameter is absent. Otherwise a new value is allocated,
+// parsed using the parser for the element type, and set using the setter for the element type.
+func (p *Parser) pointerValuesParser(t reflect.Type, style Style) (valuesParser, bool) {
+	elemParser, ok := p.valuesParser(t.Elem(), style)
+	if !ok {
+		return nil, false
+	}
+	elemSetter, ok := p.valueSetter(t.Elem())
+	if !ok {
+		return nil, false
+	}
+	return func(values []string, delimiter string) (reflect.Value, string, error) {
+		if values == nil {
+			return reflect.Zero(t), "", nil
+		}
+		parsedValue, value, err := elemParser(values, delimiter)
+		if err != nil {
+			return parsedValue, value, err
+		}
+		result := reflect.New(t.Elem())
+		if err := elemSetter(parsedValue, result.Elem()); err != nil {
+			return parsedValue, value, err
+		}
+		return result, value, nil
+	}, true
+}
+
+// pointerValuesEncoder returns a valuesEncoder for a pointer type that has no registered encoder.
+// Nil pointers are omitted, otherwise the value is encoded using the encoder for the element type.
+func (p *Parser) pointerValuesEncoder(t reflect.Type, style Style) (valuesEncoder, bool) {
+	elemEncoder, ok := p.valuesEncoder(t.Elem(), style)
+	if !ok {
+		return nil, false
+	}
+	return func(value reflect.Value, delimiter string) ([]string, error) {
+		if value.IsNil() {
+			return nil, nil
+		}
+		return elemEncoder(value.Elem(), delimiter)
+	}, true
+}
@@ -0,0 +1,103 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type pointerRequest struct {
+	Limit     *int       `queryparam:"limit"`
+	Limit32   *int32     `queryparam:"limit32"`
+	Active    *bool      `queryparam:"active"`
+	CreatedAt *time.Time `queryparam:"created-at"`
+	IDs       *[]int     `queryparam:"id"`
+}
+
+func TestParse_Pointer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Absent", func(t *testing.T) {
+		req := &pointerRequest{}
+		if err := queryparam.Parse(url.Values{}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !reflect.DeepEqual(&pointerRequest{}, req) {
+			t.Errorf("expected all pointers to be nil, got `%v`", req)
+		}
+	})
+	t.Run("Present", func(t *testing.T) {
+		urlValues := url.Values{
+			"limit":      []string{"0"},
+			"limit32":    []string{"32"},
+			"active":     []string{"false"},
+			"created-at": []string{"2019-02-05T13:32:02Z"},
+			"id":         []string{"1,2", "3"},
+		}
+		req := &pointerRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if req.Limit == nil || *req.Limit != 0 {
+			t.Errorf("unexpected limit: %v", req.Limit)
+		}
+		if req.Limit32 == nil || *req.Limit32 != 32 {
+			t.Errorf("unexpected limit32: %v", req.Limit32)
+		}
+		if req.Active == nil || *req.Active {
+			t.Errorf("unexpected active: %v", req.Active)
+		}
+		if exp := time.Date(2019, 2, 5, 13, 32, 2, 0, time.UTC); req.CreatedAt == nil || !exp.Equal(*req.CreatedAt) {
+			t.Errorf("unexpected created at: %v", req.CreatedAt)
+		}
+		if exp := []int{1, 2, 3}; req.IDs == nil || !reflect.DeepEqual(exp, *req.IDs) {
+			t.Errorf("unexpected ids: %v", req.IDs)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		req := &pointerRequest{}
+		err := queryparam.Parse(url.Values{"limit": []string{"x"}}, req)
+		var paramErr *queryparam.ErrInvalidParameterValue
+		if !errors.As(err, &paramErr) || paramErr.Parameter != "limit" {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if req.Limit != nil {
+			t.Errorf("expected limit to be nil, got %v", *req.Limit)
+		}
+	})
+	t.Run("UnhandledType", func(t *testing.T) {
+		req := &struct {
+			Items *chan int `queryparam:"items"`
+		}{}
+		err := queryparam.Parse(url.Values{}, req)
+		if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEncode_Pointer(t *testing.T) {
+	t.Parallel()
+
+	limit := 0
+	req := pointerRequest{
+		Limit: &limit,
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"limit": []string{"0"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
@@ -0,0 +1,61 @@
+package queryparam
+
+import (
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+var presentType = reflect.TypeOf(Present(false))
+
+// presentValuesParser parses a Present based on whether the parameter key exists, so that
+// ?flag= and ?flag are both treated as present.
+func presentValuesParser(values []string, _ string) (reflect.Value, string, error) {
+	return reflect.ValueOf(Present(values != nil)), firstValue(values), nil
+}
+
+// PresentSet is a set of parameter names that were present in a request.
+type PresentSet map[string]struct{}
+
+// Has returns true if the given parameter name is in the set.
+func (s PresentSet) Has(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+// Names returns the parameter names in the set, sorted alphabetically.
+func (s PresentSet) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseWithMeta works in the same way as Parse, but also returns the set of mapped parameter
+// names that were present in urlValues. A parameter is present if its key exists, even if it
+// has no value.
+func (p *Parser) ParseWithMeta(urlValues url.Values, target interface{}) (PresentSet, error) {
+	if err := p.parse(urlValues, target, nil); err != nil {
+		return nil, err
+	}
+	plan, err := p.typePlan(reflect.TypeOf(target).Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(PresentSet)
+	for name := range plan.names {
+		if _, ok := urlValues[name]; ok {
+			present[name] = struct{}{}
+		}
+	}
+	return present, nil
+}
+
+// ParseWithMeta attempts to parse query parameters from the specified URL and store any found
+// values into the given target interface, returning the set of parameter names that were present.
+func ParseWithMeta(urlValues url.Values, target interface{}) (PresentSet, error) {
+	return DefaultParser.ParseWithMeta(urlValues, target)
+}
@@ -0,0 +1,74 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type presentRequest struct {
+	Flag    queryparam.Present `queryparam:"flag"`
+	Bare    queryparam.Present `queryparam:"bare"`
+	Missing queryparam.Present `queryparam:"missing"`
+	Limit   int                `queryparam:"limit"`
+	Page    parsePage          `queryparam:"page"`
+}
+
+func TestParse_PresentKeyExists(t *testing.T) {
+	t.Parallel()
+
+	urlValues, err := url.ParseQuery("flag=&bare")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	req := &presentRequest{}
+	if err := queryparam.Parse(urlValues, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !req.Flag {
+		t.Errorf("expected flag to be present")
+	}
+	if !req.Bare {
+		t.Errorf("expected bare to be present")
+	}
+	if req.Missing {
+		t.Errorf("expected missing to not be present")
+	}
+}
+
+func TestParseWithMeta(t *testing.T) {
+	t.Parallel()
+
+	urlValues, err := url.ParseQuery("flag&limit=&page.size=10&unknown=1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	present, err := queryparam.ParseWithMeta(urlValues, &presentRequest{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := []string{"flag", "limit", "page.size"}, present.Names(); !reflect.DeepEqual(exp, got) {
+		t.Errorf("expected `%v`, got `%v`", exp, got)
+	}
+	if !present.Has("limit") || present.Has("page.number") {
+		t.Errorf("unexpected present set: %v", present)
+	}
+}
+
+func TestParseWithMeta_Error(t *testing.T) {
+	t.Parallel()
+
+	present, err := queryparam.ParseWithMeta(url.Values{"limit": []string{"x"}}, &presentRequest{})
+	var paramErr *queryparam.ErrInvalidParameterValue
+	if !errors.As(err, &paramErr) || present != nil {
+		t.Errorf("unexpected result: %v, %v", present, err)
+	}
+}
@@ -0,0 +1,165 @@
+package queryparam
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ProblemContentType is the content type of an RFC 7807 problem document.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem document.
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	// InvalidParams is the invalid-params extension member, listing each invalid parameter.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a single invalid parameter in a Problem.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+// ProblemResponder writes errors as RFC 7807 problem documents.
+// Its Respond method can be used as an ErrorResponder.
+//
+// The status code used for each error type can be configured. A zero status code uses the
+// default, which is http.StatusBadRequest for errors caused by the request and
+// http.StatusInternalServerError for errors caused by the target type.
+type ProblemResponder struct {
+	// Type is the problem type URI. Defaults to about:blank.
+	Type string
+	// InvalidParameterValueStatus is used for ErrInvalidParameterValue.
+	InvalidParameterValueStatus int
+	// ValidationStatus is used for ErrValidation.
+	ValidationStatus int
+	// UnknownParameterStatus is used for ErrUnknownParameter.
+	UnknownParameterStatus int
+	// CannotSetValueStatus is used for ErrCannotSetValue.
+	CannotSetValueStatus int
+	// InvalidTagStatus is used for ErrInvalidTag.
+	InvalidTagStatus int
+}
+
+// Respond writes the given error as a problem document.
+func (pr *ProblemResponder) Respond(w http.ResponseWriter, _ *http.Request, err error) {
+	problem := pr.Problem(err)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// Problem returns the problem document for the given error.
+// An *ErrInvalidParameters uses the highest status code of the errors it contains.
+// Detail and InvalidParams are only populated for client errors.
+func (pr *ProblemResponder) Problem(err error) *Problem {
+	problemType := pr.Type
+	if problemType == "" {
+		problemType = "about:blank"
+	}
+	problem := &Problem{
+		Type:          problemType,
+		InvalidParams: make([]InvalidParam, 0),
+	}
+
+	errs := []error{err}
+	var invalidParameters *ErrInvalidParameters
+	if errors.As(err, &invalidParameters) {
+		errs = invalidParameters.Errors
+	}
+	for _, e := range errs {
+		if status := pr.statusCode(e); status > problem.Status {
+			problem.Status = status
+		}
+		problem.InvalidParams = append(problem.InvalidParams, invalidParams(e)...)
+	}
+
+	problem.Title = http.StatusText(problem.Status)
+	if problem.Status < http.StatusInternalServerError {
+		problem.Detail = err.Error()
+	} else {
+		// don't expose details of server errors.
+		problem.InvalidParams = nil
+	}
+	return problem
+}
+
+// statusCode returns the configured status code for the given error.
+func (pr *ProblemResponder) statusCode(err error) int {
+	var invalidParameterValue *ErrInvalidParameterValue
+	var validation *ErrValidation
+	var unknownParameter *ErrUnknownParameter
+	var cannotSetValue *ErrCannotSetValue
+	switch {
+	case errors.Is(err, ErrInvalidTag):
+		return statusOrDefault(pr.InvalidTagStatus, http.StatusInternalServerError)
+	case errors.As(err, &cannotSetValue):
+		return statusOrDefault(pr.CannotSetValueStatus, http.StatusInternalServerError)
+	case errors.As(err, &invalidParameterValue):
+		return statusOrDefault(pr.InvalidParameterValueStatus, http.StatusBadRequest)
+	case errors.As(err, &validation):
+		return statusOrDefault(pr.ValidationStatus, http.StatusBadRequest)
+	case errors.As(err, &unknownParameter):
+		return statusOrDefault(pr.UnknownParameterStatus, http.StatusBadRequest)
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// statusOrDefault returns status if it is set, otherwise the default status.
+func statusOrDefault(status int, defaultStatus int) int {
+	if status == 0 {
+		return defaultStatus
+	}
+	return status
+}
+
+// invalidParams returns the invalid-params entries for the given error.
+func invalidParams(err error) []InvalidParam {
+	var invalidParameterValue *ErrInvalidParameterValue
+	if errors.As(err, &invalidParameterValue) {
+		return []InvalidParam{{
+			Name:   invalidParameterValue.Parameter,
+			Value:  invalidParameterValue.Value,
+			Reason: invalidParameterValue.Err.Error(),
+		}}
+	}
+	var cannotSetValue *ErrCannotSetValue
+	if errors.As(err, &cannotSetValue) {
+		return []InvalidParam{{
+			Name:   cannotSetValue.Parameter,
+			Value:  cannotSetValue.Value,
+			Reason: cannotSetValue.Err.Error(),
+		}}
+	}
+	var validation *ErrValidation
+	if errors.As(err, &validation) {
+		return []InvalidParam{{
+			Name:   validation.Parameter,
+			Value:  validation.Value,
+			Reason: "failed " + validation.Rule + " rule: " + validation.Arg,
+		}}
+	}
+	var unknownParameter *ErrUnknownParameter
+	if errors.As(err, &unknownParameter) {
+		res := make([]InvalidParam, len(unknownParameter.Parameters))
+		for i, parameter := range unknownParameter.Parameters {
+			res[i] = InvalidParam{
+				Name:   parameter,
+				Reason: "unknown parameter",
+			}
+			if suggestions := unknownParameter.Suggestions[parameter]; len(suggestions) > 0 {
+				res[i].Reason += ", did you mean " + suggestions[0] + "?"
+			}
+		}
+		return res
+	}
+	return nil
+}
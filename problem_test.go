@@ -0,0 +1,98 @@
+package queryparam_test
+
+import (
+	"encoding/json"
+	"github.com/tomwright/queryparam/v4"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type problemRequest struct {
+	Limit int    `queryparam:"limit" max:"100"`
+	Sort  string `queryparam:"sort" oneof:"asc desc"`
+	Age   int    `queryparam:"age"`
+}
+
+func TestProblemResponder(t *testing.T) {
+	t.Parallel()
+
+	responder := &queryparam.ProblemResponder{}
+	handler := queryparam.HandlerWith(queryparam.DefaultParser, responder.Respond, func(w http.ResponseWriter, r *http.Request, params problemRequest) {
+		t.Error("handler should not be called")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?limit=101&sort=up&age=x", nil))
+
+	if exp, got := http.StatusBadRequest, w.Code; exp != got {
+		t.Errorf("unexpected status code. expected %d, got %d", exp, got)
+	}
+	if exp, got := queryparam.ProblemContentType, w.Header().Get("Content-Type"); exp != got {
+		t.Errorf("unexpected content type. expected `%s`, got `%s`", exp, got)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if got["type"] != "about:blank" || got["title"] != "Bad Request" || got["status"] != float64(400) {
+		t.Errorf("unexpected problem: %v", got)
+	}
+	expParams := []interface{}{
+		map[string]interface{}{"name": "limit", "value": "101", "reason": "failed max rule: 100"},
+		map[string]interface{}{"name": "sort", "value": "up", "reason": "failed oneof rule: asc desc"},
+		map[string]interface{}{"name": "age", "value": "x", "reason": `strconv.ParseInt: parsing "x": invalid syntax`},
+	}
+	if !reflect.DeepEqual(expParams, got["invalid-params"]) {
+		t.Errorf("unexpected invalid params. expected `%v`, got `%v`", expParams, got["invalid-params"])
+	}
+}
+
+func TestProblemResponder_Problem(t *testing.T) {
+	t.Parallel()
+
+	responder := &queryparam.ProblemResponder{
+		Type:             "https://example.com/problems/invalid-query",
+		ValidationStatus: http.StatusUnprocessableEntity,
+	}
+
+	t.Run("ConfiguredStatus", func(t *testing.T) {
+		err := queryparam.ParseAll(url.Values{"limit": []string{"101"}}, &problemRequest{})
+		problem := responder.Problem(err)
+		if exp, got := http.StatusUnprocessableEntity, problem.Status; exp != got {
+			t.Errorf("unexpected status. expected %d, got %d", exp, got)
+		}
+		if exp, got := "https://example.com/problems/invalid-query", problem.Type; exp != got {
+			t.Errorf("unexpected type. expected `%s`, got `%s`", exp, got)
+		}
+	})
+	t.Run("HighestStatus", func(t *testing.T) {
+		err := queryparam.ParseAll(url.Values{"limit": []string{"101"}, "age": []string{"x"}}, &problemRequest{})
+		if exp, got := http.StatusUnprocessableEntity, responder.Problem(err).Status; exp != got {
+			t.Errorf("unexpected status. expected %d, got %d", exp, got)
+		}
+	})
+	t.Run("InvalidTag", func(t *testing.T) {
+		err := queryparam.Parse(url.Values{}, &struct {
+			Name string `queryparam:""`
+		}{})
+		problem := responder.Problem(err)
+		if exp, got := http.StatusInternalServerError, problem.Status; exp != got {
+			t.Errorf("unexpected status. expected %d, got %d", exp, got)
+		}
+		if problem.Detail != "" || len(problem.InvalidParams) != 0 {
+			t.Errorf("expected no details for a server error, got `%v`", problem)
+		}
+	})
+	t.Run("UnknownParameter", func(t *testing.T) {
+		err := newStrictParser().Parse(url.Values{"limt": []string{"1"}}, &problemRequest{})
+		exp := []queryparam.InvalidParam{{Name: "limt", Reason: "unknown parameter, did you mean limit?"}}
+		if got := responder.Problem(err).InvalidParams; !reflect.DeepEqual(exp, got) {
+			t.Errorf("unexpected invalid params. expected `%v`, got `%v`", exp, got)
+		}
+	})
+}
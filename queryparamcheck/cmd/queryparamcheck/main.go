@@ -0,0 +1,17 @@
+// Command queryparamcheck checks structs used with queryparam for mistakes that would otherwise
+// only be found at runtime.
+//
+// Usage:
+//
+//	queryparamcheck [-types=example.com/pkg.UserID] ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/tomwright/queryparam/v4/queryparamcheck"
+)
+
+func main() {
+	singlechecker.Main(queryparamcheck.Analyzer)
+}
@@ -0,0 +1,442 @@
+// Package queryparamcheck defines an Analyzer that checks structs used with queryparam for
+// mistakes that would otherwise only be found at runtime.
+package queryparamcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// queryparamPath is the import path of the queryparam package.
+const queryparamPath = "github.com/tomwright/queryparam/v4"
+
+// The tag names, options and styles used by queryparam.DefaultParser. They are repeated here so
+// that the analyzer does not depend on a particular release of the queryparam module.
+const (
+	tagName      = "queryparam"
+	delimiterTag = "queryparamdelim"
+	styleTag     = "queryparamstyle"
+	separator    = "."
+	remainOption = "remain"
+)
+
+// styles are the styles that can be set using the style tag.
+var styles = map[string]bool{
+	"both":      true,
+	"explode":   true,
+	"delimited": true,
+}
+
+const doc = `check structs used with queryparam
+
+The queryparam analyzer checks every struct that has a queryparam tag, and every struct that is
+passed to queryparam.Parse or a similar function, for:
+
+  - empty tags and unknown tag options, which cause an ErrInvalidTag.
+  - field types that cannot be parsed, which cause an ErrUnhandledFieldType.
+  - parameter names that are used by more than one field.
+  - delimiter tags that are empty or have no effect, and unknown styles.
+  - targets that are not a pointer to a struct.
+
+Types with a value parser that is registered at runtime should be listed in the -types flag.`
+
+// Analyzer checks structs used with queryparam.
+var Analyzer = &analysis.Analyzer{
+	Name:     "queryparam",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// customTypes is a comma separated list of types with a value parser that is registered at
+// runtime, e.g. example.com/pkg.UserID.
+var customTypes string
+
+func init() {
+	Analyzer.Flags.StringVar(&customTypes, "types", "", "comma separated list of types with a custom value parser, e.g. example.com/pkg.UserID")
+}
+
+// targetFuncs are the functions and methods of the queryparam package whose last argument is
+// a parse target.
+var targetFuncs = map[string]bool{
+	"Parse":         true,
+	"ParseAll":      true,
+	"ParseWithMeta": true,
+	"BindRequest":   true,
+}
+
+// typeParamFuncs are the generic functions of the queryparam package whose type parameter is
+// a parse target.
+var typeParamFuncs = map[string]bool{
+	"ParseAs":        true,
+	"NewDecoder":     true,
+	"Handler":        true,
+	"HandlerWith":    true,
+	"Middleware":     true,
+	"MiddlewareWith": true,
+}
+
+// builtinTypes are the types handled by queryparam.DefaultValueParsers.
+var builtinTypes = map[string]bool{
+	"string":                    true,
+	"[]string":                  true,
+	"int":                       true,
+	"int32":                     true,
+	"int64":                     true,
+	"float32":                   true,
+	"float64":                   true,
+	"time.Time":                 true,
+	"bool":                      true,
+	queryparamPath + ".Present": true,
+}
+
+// checker checks the structs in a single package.
+type checker struct {
+	pass   *analysis.Pass
+	custom map[string]bool
+	// reported is used to report each problem once, as a struct may be checked more than once.
+	reported map[token.Pos]map[string]bool
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	c := &checker{
+		pass:     pass,
+		custom:   make(map[string]bool),
+		reported: make(map[token.Pos]map[string]bool),
+	}
+	for _, name := range strings.Split(customTypes, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			c.custom[name] = true
+		}
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{
+		(*ast.StructType)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.StructType:
+			if st, ok := pass.TypesInfo.TypeOf(n).(*types.Struct); ok && hasTag(st) {
+				c.checkStruct(n.Pos(), st)
+			}
+		case *ast.CallExpr:
+			c.checkCall(n)
+		}
+	})
+	return nil, nil
+}
+
+// checkCall checks the target of a call to a queryparam function.
+func (c *checker) checkCall(call *ast.CallExpr) {
+	fun := call.Fun
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	if index, ok := fun.(*ast.IndexListExpr); ok {
+		fun = index.X
+	}
+	var ident *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return
+	}
+	obj, ok := c.pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || obj.Pkg() == nil || obj.Pkg().Path() != queryparamPath {
+		return
+	}
+
+	if typeParamFuncs[obj.Name()] {
+		if instance, ok := c.pass.TypesInfo.Instances[ident]; ok && instance.TypeArgs.Len() > 0 {
+			c.checkTypeArg(call.Pos(), instance.TypeArgs.At(0))
+		}
+		return
+	}
+	if targetFuncs[obj.Name()] && len(call.Args) > 0 {
+		target := call.Args[len(call.Args)-1]
+		c.checkTarget(target.Pos(), c.pass.TypesInfo.TypeOf(target))
+	}
+}
+
+// checkTypeArg checks that the given type argument is a struct, and checks the struct.
+func (c *checker) checkTypeArg(pos token.Pos, t types.Type) {
+	switch u := t.Underlying().(type) {
+	case *types.Interface:
+		// type parameters of generic callers are checked where they are instantiated.
+	case *types.Struct:
+		c.checkStruct(pos, u)
+	default:
+		c.report(pos, "unhandled queryparam type argument %s: must be a struct", c.typeString(t))
+	}
+}
+
+// checkTarget checks that the given type is a pointer to a struct, and checks the struct.
+func (c *checker) checkTarget(pos token.Pos, t types.Type) {
+	if t == nil {
+		return
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return
+	}
+	pointer, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		c.report(pos, "queryparam target must be a non nil pointer, got %s", c.typeString(t))
+		return
+	}
+	if _, ok := pointer.Elem().Underlying().(*types.Interface); ok {
+		return
+	}
+	st, ok := pointer.Elem().Underlying().(*types.Struct)
+	if !ok {
+		c.report(pos, "unhandled queryparam target type %s: must be a pointer to a struct", c.typeString(t))
+		return
+	}
+	c.checkStruct(pos, st)
+}
+
+// checkStruct checks each field of the given struct and of any nested structs.
+// Problems in fields outside of the current package are reported at pos.
+func (c *checker) checkStruct(pos token.Pos, st *types.Struct) {
+	s := &structCheck{
+		checker: c,
+		names:   make(map[string]string),
+	}
+	s.check(pos, "", st, map[*types.Struct]bool{})
+}
+
+// structCheck holds the state used while checking a single struct.
+type structCheck struct {
+	*checker
+	// names maps each parameter name to the field that uses it.
+	names map[string]string
+	// remain is true once a remain field has been found.
+	remain bool
+}
+
+// check checks each field in the given struct, following the same rules as the queryparam
+// Parser. seen is used to stop recursive struct types.
+func (s *structCheck) check(pos token.Pos, prefix string, st *types.Struct, seen map[*types.Struct]bool) {
+	if seen[st] {
+		return
+	}
+	seen[st] = true
+	defer delete(seen, st)
+
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		fieldPos := pos
+		if field.Pkg() == s.pass.Pkg {
+			fieldPos = field.Pos()
+		}
+		tag := reflect.StructTag(st.Tag(i))
+
+		value, ok := tag.Lookup(tagName)
+		if !ok {
+			if embedded, ok := field.Type().Underlying().(*types.Struct); ok && field.Embedded() {
+				s.check(fieldPos, prefix, embedded, seen)
+			}
+			continue
+		}
+
+		parts := strings.Split(value, ",")
+		name, options := parts[0], parts[1:]
+		if len(options) > 0 {
+			s.checkOptions(fieldPos, field, options)
+			continue
+		}
+		if name == "" {
+			s.report(fieldPos, "missing queryparam tag value for field %s", field.Name())
+			continue
+		}
+		if prefix != "" {
+			name = prefix + separator + name
+		}
+
+		if !s.valuesParser(field.Type()) {
+			if nested, ok := field.Type().Underlying().(*types.Struct); ok {
+				s.check(fieldPos, name, nested, seen)
+				continue
+			}
+			s.report(fieldPos, "unhandled queryparam field type for field %s: %s", field.Name(), s.typeString(field.Type()))
+			continue
+		}
+
+		if other, ok := s.names[name]; ok {
+			s.report(fieldPos, "duplicate queryparam parameter name %q for field %s, also used by field %s", name, field.Name(), other)
+		} else {
+			s.names[name] = field.Name()
+		}
+
+		s.checkDelimiter(fieldPos, field, tag)
+	}
+}
+
+// checkOptions checks the tag options of the given field.
+func (s *structCheck) checkOptions(pos token.Pos, field *types.Var, options []string) {
+	for _, option := range options {
+		if option != remainOption {
+			s.report(pos, "unknown queryparam tag option %q for field %s", option, field.Name())
+			return
+		}
+	}
+	if !isRemainType(field.Type()) {
+		s.report(pos, "queryparam %s field %s must be url.Values or map[string][]string, got %s", remainOption, field.Name(), s.typeString(field.Type()))
+		return
+	}
+	if s.remain {
+		s.report(pos, "multiple queryparam %s fields: %s", remainOption, field.Name())
+	}
+	s.remain = true
+}
+
+// checkDelimiter checks the delimiter and style tags of the given field.
+func (s *structCheck) checkDelimiter(pos token.Pos, field *types.Var, tag reflect.StructTag) {
+	if style, ok := tag.Lookup(styleTag); ok && style != "" {
+		if !styles[style] {
+			s.report(pos, "unknown queryparam style %q for field %s", style, field.Name())
+		}
+	}
+
+	delimiter, ok := tag.Lookup(delimiterTag)
+	if !ok {
+		return
+	}
+	if delimiter == "" {
+		s.report(pos, "empty %s tag for field %s has no effect", delimiterTag, field.Name())
+		return
+	}
+	t := valueType(field.Type())
+	if _, ok := t.Underlying().(*types.Slice); !ok && builtinTypes[s.typeString(t)] {
+		s.report(pos, "%s tag for field %s has no effect on %s", delimiterTag, field.Name(), s.typeString(t))
+	}
+}
+
+// valuesParser returns true if the parser can read the given field type, in the same way as
+// Parser.valuesParser.
+func (s *structCheck) valuesParser(t types.Type) bool {
+	if arg, ok := optionalArg(t); ok {
+		return s.valuesParser(arg)
+	}
+	if pointer, ok := t.Underlying().(*types.Pointer); ok && !s.valueParser(t) {
+		return s.valuesParser(pointer.Elem())
+	}
+	return s.valueParser(t)
+}
+
+// valueParser returns true if the parser has a value parser for the given type, in the same
+// way as Parser.valueParser.
+func (s *structCheck) valueParser(t types.Type) bool {
+	if hasMethod(t, "UnmarshalQueryParam") {
+		return true
+	}
+	name := s.typeString(t)
+	if builtinTypes[name] || s.custom[name] {
+		return true
+	}
+	if hasMethod(t, "UnmarshalText") {
+		return true
+	}
+	if slice, ok := t.Underlying().(*types.Slice); ok {
+		return s.valueParser(slice.Elem())
+	}
+	return false
+}
+
+// report reports a problem at the given position, unless it has already been reported.
+func (c *checker) report(pos token.Pos, format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	if c.reported[pos] == nil {
+		c.reported[pos] = make(map[string]bool)
+	}
+	if c.reported[pos][message] {
+		return
+	}
+	c.reported[pos][message] = true
+	c.pass.Reportf(pos, "%s", message)
+}
+
+// typeString returns the fully qualified name of the given type.
+func (c *checker) typeString(t types.Type) string {
+	return types.TypeString(t, nil)
+}
+
+// hasTag returns true if any field in the struct, or in an embedded struct, has a queryparam tag.
+func hasTag(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup(tagName); ok {
+			return true
+		}
+		if embedded, ok := st.Field(i).Type().Underlying().(*types.Struct); ok && st.Field(i).Embedded() && hasTag(embedded) {
+			return true
+		}
+	}
+	return false
+}
+
+// optionalArg returns the type argument of a queryparam.Optional type.
+func optionalArg(t types.Type) (types.Type, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+	obj := named.Origin().Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != queryparamPath || obj.Name() != "Optional" {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+// valueType returns the type of the value held by the given type, unwrapping any pointer and
+// Optional types.
+func valueType(t types.Type) types.Type {
+	for {
+		if pointer, ok := t.(*types.Pointer); ok {
+			t = pointer.Elem()
+		} else if arg, ok := optionalArg(t); ok {
+			t = arg
+		} else {
+			return t
+		}
+	}
+}
+
+// hasMethod returns true if a pointer to the given type has the named method.
+func hasMethod(t types.Type, name string) bool {
+	if _, ok := t.(*types.Pointer); ok {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), true, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// isRemainType returns true if the given type can be used as a remain field.
+func isRemainType(t types.Type) bool {
+	m, ok := t.Underlying().(*types.Map)
+	if !ok {
+		return false
+	}
+	key, ok := m.Key().Underlying().(*types.Basic)
+	if !ok || key.Kind() != types.String {
+		return false
+	}
+	elem, ok := m.Elem().Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	item, ok := elem.Elem().Underlying().(*types.Basic)
+	return ok && item.Kind() == types.String
+}
@@ -0,0 +1,16 @@
+package queryparamcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/tomwright/queryparam/v4/queryparamcheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	if err := queryparamcheck.Analyzer.Flags.Set("types", "a.ID"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), queryparamcheck.Analyzer, "a")
+}
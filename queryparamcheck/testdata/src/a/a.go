@@ -0,0 +1,86 @@
+package a
+
+import (
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/tomwright/queryparam/v4"
+
+	"b"
+)
+
+type ID string
+
+type BBox [4]float64
+
+func (b *BBox) UnmarshalQueryParam(value string, delimiter string) error { return nil }
+
+type Page struct {
+	Size   int `queryparam:"size"`
+	Number int `queryparam:"number"`
+}
+
+type Common struct {
+	Name string `queryparam:"name"`
+}
+
+type Valid struct {
+	Common
+	IDs     []int                       `queryparam:"id" queryparamdelim:"|" queryparamstyle:"delimited"`
+	Since   *time.Time                  `queryparam:"since"`
+	Debug   queryparam.Present          `queryparam:"debug"`
+	Sort    queryparam.Optional[string] `queryparam:"sort"`
+	IP      net.IP                      `queryparam:"ip"`
+	BBox    BBox                        `queryparam:"bbox" queryparamdelim:";"`
+	Custom  ID                          `queryparam:"custom"`
+	Page    Page                        `queryparam:"page"`
+	Other   url.Values                  `queryparam:",remain"`
+	Ignored map[string]string
+}
+
+type Invalid struct {
+	Empty     string            `queryparam:""`         // want `missing queryparam tag value for field Empty`
+	Option    string            `queryparam:"option,x"` // want `unknown queryparam tag option "x" for field Option`
+	Map       map[string]string `queryparam:"map"`      // want `unhandled queryparam field type for field Map: map\[string\]string`
+	Uint      uint              `queryparam:"uint"`     // want `unhandled queryparam field type for field Uint: uint`
+	Pointers  []*int            `queryparam:"pointers"` // want `unhandled queryparam field type for field Pointers: \[\]\*int`
+	Duplicate string            `queryparam:"name"`
+	Again     string            `queryparam:"name"`                       // want `duplicate queryparam parameter name "name" for field Again, also used by field Duplicate`
+	Delim     []int             `queryparam:"delim" queryparamdelim:""`   // want `empty queryparamdelim tag for field Delim has no effect`
+	Scalar    int               `queryparam:"scalar" queryparamdelim:"|"` // want `queryparamdelim tag for field Scalar has no effect on int`
+	Style     []int             `queryparam:"style" queryparamstyle:"x"`  // want `unknown queryparam style "x" for field Style`
+	Remain    map[string]string `queryparam:",remain"`                    // want `queryparam remain field Remain must be url.Values or map\[string\]\[\]string, got map\[string\]string`
+}
+
+type Nested struct {
+	Page
+	Size int  `queryparam:"size"` // want `duplicate queryparam parameter name "size" for field Size, also used by field Size`
+	Sub  Page `queryparam:"page"`
+	Dup  int  `queryparam:"page.number"` // want `duplicate queryparam parameter name "page.number" for field Dup, also used by field Number`
+}
+
+type Remains struct {
+	A url.Values          `queryparam:",remain"`
+	B map[string][]string `queryparam:",remain"` // want `multiple queryparam remain fields: B`
+}
+
+type untagged struct {
+	Map map[string]string
+}
+
+type Wrapper struct {
+	Bad b.Bad `queryparam:"bad"` // want `unhandled queryparam field type for field Map: map\[string\]string`
+}
+
+func calls(urlValues url.Values) {
+	_ = queryparam.Parse(urlValues, Valid{})  // want `queryparam target must be a non nil pointer, got a.Valid`
+	_ = queryparam.Parse(urlValues, new(int)) // want `unhandled queryparam target type \*int: must be a pointer to a struct`
+	_ = queryparam.ParseAll(urlValues, &untagged{})
+	_ = queryparam.ParseAll(urlValues, &b.Bad{}) // want `unhandled queryparam field type for field Map: map\[string\]string`
+	_ = (&queryparam.Parser{}).Parse(urlValues, &Valid{})
+	_, _ = queryparam.ParseAs[b.Bad](urlValues)  // want `unhandled queryparam field type for field Map: map\[string\]string`
+	_, _ = queryparam.ParseAs[*Valid](urlValues) // want `unhandled queryparam type argument \*a.Valid: must be a struct`
+	var target interface{} = &Valid{}
+	_ = queryparam.Parse(urlValues, target)
+}
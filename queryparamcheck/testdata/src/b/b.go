@@ -0,0 +1,5 @@
+package b
+
+type Bad struct {
+	Map map[string]string `queryparam:"map"`
+}
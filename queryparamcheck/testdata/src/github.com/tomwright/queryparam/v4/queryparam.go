@@ -0,0 +1,30 @@
+// Package queryparam is a stub of the queryparam API used by the analyzer tests.
+package queryparam
+
+import (
+	"net/http"
+	"net/url"
+)
+
+type Present bool
+
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+type Parser struct{}
+
+func (p *Parser) Parse(urlValues url.Values, target interface{}) error { return nil }
+
+func Parse(urlValues url.Values, target interface{}) error { return nil }
+
+func ParseAll(urlValues url.Values, target interface{}) error { return nil }
+
+func BindRequest(r *http.Request, target interface{}) error { return nil }
+
+func ParseAs[T any](urlValues url.Values) (T, error) {
+	var t T
+	return t, nil
+}
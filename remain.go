@@ -0,0 +1,76 @@
+package queryparam
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// TagOptionRemain is a tag option that marks a url.Values or map[string][]string field as the
+// catch-all for every parameter that is not mapped to another field, e.g. `queryparam:",remain"`.
+const TagOptionRemain = "remain"
+
+var urlValuesType = reflect.TypeOf(url.Values{})
+
+// parseTag splits a tag value into the parameter name and any comma separated options.
+func parseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+// checkRemainField returns an error if the given field cannot be used as a remain field.
+func checkRemainField(field reflect.StructField) error {
+	t := field.Type
+	if t.Kind() != reflect.Map || t.Key().Kind() != reflect.String || t.Elem().Kind() != reflect.Slice || t.Elem().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%w: %s: %v must be %v to use the %s option", ErrUnhandledFieldType, field.Name, t, urlValuesType, TagOptionRemain)
+	}
+	return nil
+}
+
+// compileRemain sets the remain field on the type plan.
+func (p *Parser) compileRemain(plan *typePlan, index []int, field reflect.StructField) error {
+	if err := checkRemainField(field); err != nil {
+		return err
+	}
+	if plan.remain != nil {
+		return fmt.Errorf("multiple %s fields: %s: %w", TagOptionRemain, field.Name, ErrInvalidTag)
+	}
+	plan.remain = index
+	return nil
+}
+
+// parseRemain sets every parameter that is not mapped to a field onto the given remain field value.
+// If merge is true the parameters are added to any existing map, and a nil map is left untouched
+// when no parameters remain.
+func (plan *typePlan) parseRemain(value reflect.Value, urlValues url.Values, merge bool) {
+	remain := reflect.MakeMap(value.Type())
+	if merge && !value.IsNil() {
+		remain = value
+	}
+	for name, values := range urlValues {
+		if _, ok := plan.names[name]; ok {
+			continue
+		}
+		remainValues := reflect.MakeSlice(value.Type().Elem(), len(values), len(values))
+		for i, v := range values {
+			remainValues.Index(i).SetString(v)
+		}
+		remain.SetMapIndex(reflect.ValueOf(name).Convert(value.Type().Key()), remainValues)
+	}
+	if merge && remain.Len() == 0 {
+		return
+	}
+	value.Set(remain)
+}
+
+// encodeRemain adds every value in the given remain field value to urlValues.
+func encodeRemain(value reflect.Value, urlValues url.Values) {
+	iter := value.MapRange()
+	for iter.Next() {
+		name := iter.Key().String()
+		for i := 0; i < iter.Value().Len(); i++ {
+			urlValues.Add(name, iter.Value().Index(i).String())
+		}
+	}
+}
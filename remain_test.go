@@ -0,0 +1,163 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+type remainRequest struct {
+	Limit int        `queryparam:"limit"`
+	Page  parsePage  `queryparam:"page"`
+	Rest  url.Values `queryparam:",remain"`
+}
+
+func TestParse_Remain(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{
+		"limit":     []string{"10"},
+		"page.size": []string{"5"},
+		"q":         []string{"tom"},
+		"tag":       []string{"a", "b"},
+	}
+
+	t.Run("URLValues", func(t *testing.T) {
+		req := &remainRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := url.Values{
+			"q":   []string{"tom"},
+			"tag": []string{"a", "b"},
+		}
+		if !reflect.DeepEqual(exp, req.Rest) {
+			t.Errorf("expected `%v`, got `%v`", exp, req.Rest)
+		}
+		if exp, got := 10, req.Limit; exp != got {
+			t.Errorf("unexpected limit. expected `%v`, got `%v`", exp, got)
+		}
+	})
+	t.Run("Map", func(t *testing.T) {
+		req := &struct {
+			Limit int                 `queryparam:"limit"`
+			Rest  map[string][]string `queryparam:",remain"`
+		}{}
+		if err := newStrictParser().Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := map[string][]string{
+			"page.size": {"5"},
+			"q":         {"tom"},
+			"tag":       {"a", "b"},
+		}
+		if !reflect.DeepEqual(exp, req.Rest) {
+			t.Errorf("expected `%v`, got `%v`", exp, req.Rest)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		req := &remainRequest{}
+		if err := queryparam.Parse(url.Values{"limit": []string{"10"}}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if req.Rest == nil || len(req.Rest) != 0 {
+			t.Errorf("expected empty remain values, got `%v`", req.Rest)
+		}
+	})
+}
+
+func TestParse_RemainMerge(t *testing.T) {
+	t.Parallel()
+
+	p := newStrictParser()
+	p.Merge = true
+
+	t.Run("Existing", func(t *testing.T) {
+		req := &remainRequest{Rest: url.Values{"keep": []string{"1"}, "q": []string{"jim"}}}
+		if err := p.Parse(url.Values{"limit": []string{"10"}, "q": []string{"tom"}}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		exp := url.Values{
+			"keep": []string{"1"},
+			"q":    []string{"tom"},
+		}
+		if !reflect.DeepEqual(exp, req.Rest) {
+			t.Errorf("expected `%v`, got `%v`", exp, req.Rest)
+		}
+	})
+	t.Run("NothingRemains", func(t *testing.T) {
+		req := &remainRequest{}
+		if err := p.Parse(url.Values{"limit": []string{"10"}}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if req.Rest != nil {
+			t.Errorf("expected remain values to be left nil, got `%v`", req.Rest)
+		}
+	})
+}
+
+func TestParse_RemainInvalid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Type", func(t *testing.T) {
+		req := &struct {
+			Rest map[string]string `queryparam:",remain"`
+		}{}
+		err := queryparam.Parse(url.Values{}, req)
+		if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		req := &struct {
+			Rest  url.Values `queryparam:",remain"`
+			Other url.Values `queryparam:",remain"`
+		}{}
+		err := queryparam.Parse(url.Values{}, req)
+		if !errors.Is(err, queryparam.ErrInvalidTag) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("UnknownOption", func(t *testing.T) {
+		req := &struct {
+			Name string `queryparam:"name,unknown"`
+		}{}
+		err := queryparam.Parse(url.Values{}, req)
+		if !errors.Is(err, queryparam.ErrInvalidTag) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEncode_Remain(t *testing.T) {
+	t.Parallel()
+
+	req := remainRequest{
+		Limit: 10,
+		Rest: url.Values{
+			"q": []string{"tom"},
+		},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"limit":       []string{"10"},
+		"page.size":   []string{"0"},
+		"page.number": []string{"0"},
+		"q":           []string{"tom"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
@@ -17,6 +17,16 @@ func DefaultValueSetters() map[reflect.Type]ValueSetter {
 	}
 }
 
+// valueSetter returns the value setter for the given type, falling back to the generic
+// value setter.
+func (p *Parser) valueSetter(t reflect.Type) (ValueSetter, bool) {
+	valueSetter, ok := p.ValueSetters[t]
+	if !ok {
+		valueSetter, ok = p.ValueSetters[GenericType]
+	}
+	return valueSetter, ok
+}
+
 // recoverPanic recovers from a panic and sets the panic value into the given error.
 func recoverPanic(err *error) func() {
 	return func() {
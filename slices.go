@@ -0,0 +1,246 @@
+package queryparam
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidSliceItem is an error that adds the index of the failing item to a slice
+// item parser or setter error.
+type ErrInvalidSliceItem struct {
+	Err   error
+	Index int
+	Value string
+}
+
+// Error returns the full error message.
+func (e *ErrInvalidSliceItem) Error() string {
+	return fmt.Sprintf("invalid item at index %d (%s): %s", e.Index, e.Value, e.Err.Error())
+}
+
+// Unwrap returns the wrapped error.
+func (e *ErrInvalidSliceItem) Unwrap() error {
+	return e.Err
+}
+
+// Style defines how the values of a slice field are read from the query parameters.
+type Style string
+
+const (
+	// StyleBoth collects every value of a repeated parameter and splits each value
+	// on the field delimiter, e.g. ?id=1,2&id=3.
+	StyleBoth Style = "both"
+	// StyleExplode collects every value of a repeated parameter without splitting
+	// them, e.g. ?id=1&id=2&id=3.
+	StyleExplode Style = "explode"
+	// StyleDelimited splits the first value of the parameter on the field delimiter,
+	// e.g. ?id=1,2,3.
+	StyleDelimited Style = "delimited"
+)
+
+// FieldStyle returns the style to be used with the given field.
+func (p *Parser) FieldStyle(field reflect.StructField) (Style, error) {
+	style := p.Style
+	if customStyle := field.Tag.Get(p.StyleTag); customStyle != "" {
+		style = Style(customStyle)
+	}
+	switch style {
+	case "":
+		return StyleBoth, nil
+	case StyleBoth, StyleExplode, StyleDelimited:
+		return style, nil
+	default:
+		return "", fmt.Errorf("unknown style %q for field: %s: %w", style, field.Name, ErrInvalidTag)
+	}
+}
+
+// isSliceType returns true if the given type is a slice that should be affected by the field
+// style. Slice types that parse themselves, such as net.IP, are treated as single values.
+func isSliceType(t reflect.Type) bool {
+	if t.Kind() != reflect.Slice {
+		return false
+	}
+	ptr := reflect.PtrTo(t)
+	return !ptr.Implements(unmarshalerType) && !ptr.Implements(textUnmarshalerType)
+}
+
+// valuesParser is a func used to parse every value of a parameter.
+// The raw value that could not be parsed is returned alongside any error.
+type valuesParser func(values []string, delimiter string) (reflect.Value, string, error)
+
+// valuesParser returns a valuesParser for the given type and style.
+// Only slice types are affected by the style.
+func (p *Parser) valuesParser(t reflect.Type, style Style) (valuesParser, bool) {
+	if isOptionalType(t) {
+		return p.optionalValuesParser(t, style)
+	}
+	if _, ok := p.valueParser(t); !ok && t.Kind() == reflect.Ptr {
+		return p.pointerValuesParser(t, style)
+	}
+	if isSliceType(t) && style == StyleExplode {
+		itemParser, ok := p.valueParser(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return func(values []string, delimiter string) (reflect.Value, string, error) {
+			return p.parseSliceItems(t, itemParser, values, delimiter)
+		}, true
+	}
+
+	if t == presentType {
+		return presentValuesParser, true
+	}
+
+	valueParser, ok := p.valueParser(t)
+	if !ok {
+		return nil, false
+	}
+
+	if isSliceType(t) && style == StyleBoth {
+		return func(values []string, delimiter string) (reflect.Value, string, error) {
+			if len(values) == 0 {
+				parsedValue, err := valueParser("", delimiter)
+				return parsedValue, "", err
+			}
+			var result reflect.Value
+			for i, value := range values {
+				parsedValue, err := valueParser(value, delimiter)
+				if err != nil {
+					// offset the item index by the items parsed from the previous values.
+					var itemErr *ErrInvalidSliceItem
+					if i > 0 && errors.As(err, &itemErr) {
+						err = &ErrInvalidSliceItem{Err: itemErr.Err, Index: itemErr.Index + result.Len(), Value: itemErr.Value}
+					}
+					return parsedValue, value, err
+				}
+				if i == 0 {
+					result = parsedValue
+				} else {
+					result = reflect.AppendSlice(result, parsedValue)
+				}
+			}
+			return result, values[0], nil
+		}, true
+	}
+
+	return func(values []string, delimiter string) (reflect.Value, string, error) {
+		value := firstValue(values)
+		parsedValue, err := valueParser(value, delimiter)
+		return parsedValue, value, err
+	}, true
+}
+
+// firstValue returns the first of the given values, or an empty string if there are none.
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// sliceValueParser returns a ValueParser for a slice type that has no registered parser.
+// The value is split on the delimiter and each item is parsed using the parser registered
+// for the element type.
+func (p *Parser) sliceValueParser(t reflect.Type) (ValueParser, bool) {
+	itemParser, ok := p.valueParser(t.Elem())
+	if !ok {
+		return nil, false
+	}
+	return func(value string, delimiter string) (reflect.Value, error) {
+		if value == "" {
+			// ignore blank values.
+			return reflect.MakeSlice(t, 0, 0), nil
+		}
+		parsedValue, _, err := p.parseSliceItems(t, itemParser, strings.Split(value, delimiter), delimiter)
+		return parsedValue, err
+	}, true
+}
+
+// parseSliceItems parses each item using the given item parser and sets them into a new slice
+// of the given type. Errors are returned as an *ErrInvalidSliceItem.
+func (p *Parser) parseSliceItems(t reflect.Type, itemParser ValueParser, items []string, delimiter string) (reflect.Value, string, error) {
+	itemSetter, ok := p.valueSetter(t.Elem())
+	if !ok {
+		return reflect.Value{}, "", ErrUnhandledFieldType
+	}
+	result := reflect.MakeSlice(t, len(items), len(items))
+	for i, item := range items {
+		parsedItem, err := itemParser(item, delimiter)
+		if err != nil {
+			return result, item, &ErrInvalidSliceItem{Err: err, Index: i, Value: item}
+		}
+		if err := itemSetter(parsedItem, result.Index(i)); err != nil {
+			return result, item, &ErrInvalidSliceItem{Err: err, Index: i, Value: item}
+		}
+	}
+	return result, firstValue(items), nil
+}
+
+// valuesEncoder is a func used to encode a value into every value of a parameter.
+type valuesEncoder func(value reflect.Value, delimiter string) ([]string, error)
+
+// valuesEncoder returns a valuesEncoder for the given type and style.
+// Slices using StyleExplode are encoded as a repeated parameter, everything else is
+// encoded as a single value.
+func (p *Parser) valuesEncoder(t reflect.Type, style Style) (valuesEncoder, bool) {
+	if isOptionalType(t) {
+		return p.optionalValuesEncoder(t, style)
+	}
+	if _, ok := p.valueEncoder(t); !ok && t.Kind() == reflect.Ptr {
+		return p.pointerValuesEncoder(t, style)
+	}
+	if isSliceType(t) && style == StyleExplode {
+		itemEncoder, ok := p.valueEncoder(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return func(value reflect.Value, delimiter string) ([]string, error) {
+			return encodeSliceItems(itemEncoder, value, delimiter)
+		}, true
+	}
+
+	valueEncoder, ok := p.valueEncoder(t)
+	if !ok {
+		return nil, false
+	}
+	return func(value reflect.Value, delimiter string) ([]string, error) {
+		encodedValue, err := valueEncoder(value, delimiter)
+		if err != nil || encodedValue == "" {
+			return nil, err
+		}
+		return []string{encodedValue}, nil
+	}, true
+}
+
+// sliceValueEncoder returns a ValueEncoder for a slice type that has no registered encoder.
+// Each item is encoded using the encoder registered for the element type and the results
+// are joined with the delimiter.
+func (p *Parser) sliceValueEncoder(t reflect.Type) (ValueEncoder, bool) {
+	itemEncoder, ok := p.valueEncoder(t.Elem())
+	if !ok {
+		return nil, false
+	}
+	return func(value reflect.Value, delimiter string) (string, error) {
+		encodedValues, err := encodeSliceItems(itemEncoder, value, delimiter)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(encodedValues, delimiter), nil
+	}, true
+}
+
+// encodeSliceItems encodes each item in the given slice using the given item encoder.
+// Errors are returned as an *ErrInvalidSliceItem.
+func encodeSliceItems(itemEncoder ValueEncoder, value reflect.Value, delimiter string) ([]string, error) {
+	encodedValues := make([]string, value.Len())
+	for i := range encodedValues {
+		encodedValue, err := itemEncoder(value.Index(i), delimiter)
+		if err != nil {
+			return nil, &ErrInvalidSliceItem{Err: err, Index: i, Value: encodedValue}
+		}
+		encodedValues[i] = encodedValue
+	}
+	return encodedValues, nil
+}
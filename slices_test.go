@@ -0,0 +1,242 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var urlValuesRepeatedIDs = url.Values{
+	"id": []string{"1,2", "3"},
+}
+
+func TestParse_SliceStyle(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Both      []string `queryparam:"id"`
+		Explode   []string `queryparam:"id" queryparamstyle:"explode"`
+		Delimited []string `queryparam:"id" queryparamstyle:"delimited"`
+		Missing   []string `queryparam:"missing" queryparamstyle:"explode"`
+	}{}
+
+	if err := queryparam.Parse(urlValuesRepeatedIDs, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := []string{"1", "2", "3"}, req.Both; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected both. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []string{"1,2", "3"}, req.Explode; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected explode. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []string{"1", "2"}, req.Delimited; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected delimited. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []string{}, req.Missing; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected missing. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_SliceStyleParserDefault(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		StyleTag:     "queryparamstyle",
+		Style:        queryparam.StyleDelimited,
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+
+	req := &struct {
+		IDs []string `queryparam:"id"`
+	}{}
+
+	if err := p.Parse(urlValuesRepeatedIDs, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := []string{"1", "2"}, req.IDs; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected ids. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_InvalidSliceStyle(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		IDs []string `queryparam:"id" queryparamstyle:"unknown"`
+	}{}
+
+	err := queryparam.Parse(urlValuesRepeatedIDs, req)
+	if !errors.Is(err, queryparam.ErrInvalidTag) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncode_SliceStyle(t *testing.T) {
+	t.Parallel()
+
+	req := struct {
+		Delimited []string `queryparam:"delimited"`
+		Explode   []string `queryparam:"explode" queryparamstyle:"explode"`
+	}{
+		Delimited: []string{"1", "2"},
+		Explode:   []string{"3", "", "4"},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"delimited": []string{"1,2"},
+		"explode":   []string{"3", "", "4"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
+
+type sliceCustomType string
+
+func TestParse_GenericSlice(t *testing.T) {
+	t.Parallel()
+
+	p := &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+	p.ValueParsers[reflect.TypeOf(sliceCustomType(""))] = func(value string, _ string) (reflect.Value, error) {
+		return reflect.ValueOf(sliceCustomType(value)), nil
+	}
+
+	urlValues := url.Values{
+		"ints":     []string{"1,2", "3"},
+		"int32s":   []string{"1-2-3"},
+		"float32s": []string{"1.5,2.5"},
+		"customs":  []string{"a", "b"},
+		"empty":    []string{""},
+	}
+
+	req := &struct {
+		Ints     []int             `queryparam:"ints"`
+		Int32s   []int32           `queryparam:"int32s" queryparamdelim:"-"`
+		Float32s []float32         `queryparam:"float32s"`
+		Customs  []sliceCustomType `queryparam:"customs" queryparamstyle:"explode"`
+		Empty    []int             `queryparam:"empty"`
+	}{}
+
+	if err := p.Parse(urlValues, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	if exp, got := []int{1, 2, 3}, req.Ints; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected ints. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []int32{1, 2, 3}, req.Int32s; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected int32s. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []float32{1.5, 2.5}, req.Float32s; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected float32s. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []sliceCustomType{"a", "b"}, req.Customs; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected customs. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := []int{}, req.Empty; !reflect.DeepEqual(exp, got) {
+		t.Errorf("unexpected empty. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_GenericSliceInvalidItem(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Ints []int `queryparam:"ints"`
+	}{}
+
+	err := queryparam.Parse(url.Values{"ints": []string{"1,x,3"}}, req)
+	var paramErr *queryparam.ErrInvalidParameterValue
+	var itemErr *queryparam.ErrInvalidSliceItem
+	if !errors.As(err, &paramErr) || !errors.As(err, &itemErr) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := 1, itemErr.Index; exp != got {
+		t.Errorf("unexpected index. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := "x", itemErr.Value; exp != got {
+		t.Errorf("unexpected value. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_GenericSliceInvalidItemRepeated(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Ints []int `queryparam:"ints"`
+	}{}
+
+	err := queryparam.Parse(url.Values{"ints": []string{"1", "2,x"}}, req)
+	var itemErr *queryparam.ErrInvalidSliceItem
+	if !errors.As(err, &itemErr) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := 2, itemErr.Index; exp != got {
+		t.Errorf("unexpected index. expected `%v`, got `%v`", exp, got)
+	}
+	if exp, got := "x", itemErr.Value; exp != got {
+		t.Errorf("unexpected value. expected `%v`, got `%v`", exp, got)
+	}
+}
+
+func TestParse_GenericSliceUnhandledItemType(t *testing.T) {
+	t.Parallel()
+
+	req := &struct {
+		Items []chan int `queryparam:"items"`
+	}{}
+
+	err := queryparam.Parse(url.Values{}, req)
+	if !errors.Is(err, queryparam.ErrUnhandledFieldType) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestEncode_GenericSlice(t *testing.T) {
+	t.Parallel()
+
+	req := struct {
+		Ints   []int     `queryparam:"ints"`
+		Floats []float64 `queryparam:"floats" queryparamstyle:"explode"`
+	}{
+		Ints:   []int{1, 2, 3},
+		Floats: []float64{1.5, 2},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"ints":   []string{"1,2,3"},
+		"floats": []string{"1.5", "2"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
@@ -0,0 +1,115 @@
+package queryparam
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Source is a part of a request that parameter values can be read from.
+type Source string
+
+const (
+	// SourceQuery reads values from the URL query. Its names are set using Parser.Tag.
+	SourceQuery Source = "query"
+	// SourceHeader reads values from the request headers.
+	SourceHeader Source = "header"
+	// SourceCookie reads values from the request cookies.
+	SourceCookie Source = "cookie"
+	// SourcePath reads values from the path wildcards matched by http.ServeMux, using
+	// http.Request.PathValue. It is never present when built with Go versions before 1.22.
+	SourcePath Source = "path"
+	// SourceForm reads values from a url encoded or multipart request body.
+	SourceForm Source = "form"
+)
+
+// maxFormMemory is the maximum number of bytes of a multipart body stored in memory.
+const maxFormMemory = 32 << 20
+
+// DefaultSources returns the sources read by BindRequest, in order of precedence.
+func DefaultSources() []Source {
+	return []Source{SourcePath, SourceQuery, SourceForm, SourceHeader, SourceCookie}
+}
+
+// DefaultSourceTags returns the struct tag names used to set the parameter name of each
+// source other than SourceQuery.
+func DefaultSourceTags() map[Source]string {
+	return map[Source]string{
+		SourceHeader: "header",
+		SourceCookie: "cookie",
+		SourcePath:   "path",
+		SourceForm:   "form",
+	}
+}
+
+// querySources are the sources of plans that only read the query.
+var querySources = []Source{SourceQuery}
+
+// fieldSource is a parameter name of a field within a single source.
+type fieldSource struct {
+	source Source
+	name   string
+}
+
+// valuesLookup returns the values of the named parameter within the given source, and
+// whether the parameter is present.
+type valuesLookup func(source Source, name string) ([]string, bool)
+
+// pathValuer is implemented by *http.Request from Go 1.22.
+type pathValuer interface {
+	PathValue(name string) string
+}
+
+// sourceTag returns the name of the struct tag used to set the parameter name of the given source.
+func (p *Parser) sourceTag(source Source) string {
+	if source == SourceQuery {
+		return p.Tag
+	}
+	return p.SourceTags[source]
+}
+
+// requestLookup returns a valuesLookup that reads values from the given request.
+// The request body is only parsed if the plan reads from SourceForm.
+func requestLookup(r *http.Request, urlValues url.Values, plan *typePlan) (valuesLookup, error) {
+	var form url.Values
+	if plan.reads(SourceForm) {
+		if err := r.ParseMultipartForm(maxFormMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+			return nil, err
+		}
+		form = r.PostForm
+	}
+	var cookies []*http.Cookie
+	return func(source Source, name string) ([]string, bool) {
+		switch source {
+		case SourceQuery:
+			values, ok := urlValues[name]
+			return values, ok
+		case SourceHeader:
+			values := r.Header.Values(name)
+			return values, len(values) > 0
+		case SourceCookie:
+			if cookies == nil {
+				cookies = r.Cookies()
+			}
+			var values []string
+			for _, cookie := range cookies {
+				if cookie.Name == name {
+					values = append(values, cookie.Value)
+				}
+			}
+			return values, len(values) > 0
+		case SourcePath:
+			if valuer, ok := interface{}(r).(pathValuer); ok {
+				if value := valuer.PathValue(name); value != "" {
+					return []string{value}, true
+				}
+			}
+			return nil, false
+		case SourceForm:
+			values, ok := form[name]
+			return values, ok
+		default:
+			return nil, false
+		}
+	}, nil
+}
@@ -0,0 +1,234 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type sourceRequest struct {
+	ID        int      `path:"id"`
+	Name      string   `queryparam:"name"`
+	Tags      []string `form:"tag"`
+	RequestID string   `header:"X-Request-Id"`
+	Session   string   `cookie:"session"`
+	Limit     int      `queryparam:"limit" header:"X-Limit" default:"10"`
+}
+
+// setPathValue sets a path value on the request if the Go version supports it.
+func setPathValue(t *testing.T, r *http.Request, name string, value string) {
+	setter, ok := interface{}(r).(interface{ SetPathValue(name, value string) })
+	if !ok {
+		t.Skip("path values require go 1.22")
+	}
+	setter.SetPathValue(name, value)
+}
+
+func newSourceParser(sources ...queryparam.Source) *queryparam.Parser {
+	return &queryparam.Parser{
+		Tag:          "queryparam",
+		Delimiter:    ",",
+		Separator:    ".",
+		DefaultTag:   "default",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+		Sources:      sources,
+		SourceTags:   queryparam.DefaultSourceTags(),
+	}
+}
+
+func TestBindRequest_Sources(t *testing.T) {
+	t.Parallel()
+
+	body := url.Values{"tag": {"a", "b"}}.Encode()
+	r := httptest.NewRequest(http.MethodPost, "/?name=tom", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Request-Id", "abc")
+	r.Header.Set("X-Limit", "20")
+	r.AddCookie(&http.Cookie{Name: "session", Value: "xyz"})
+	setPathValue(t, r, "id", "42")
+
+	req := &sourceRequest{}
+	if err := queryparam.BindRequest(r, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := sourceRequest{ID: 42, Name: "tom", Tags: []string{"a", "b"}, RequestID: "abc", Session: "xyz", Limit: 20}
+	if exp.ID != req.ID || exp.Name != req.Name || strings.Join(exp.Tags, ",") != strings.Join(req.Tags, ",") ||
+		exp.RequestID != req.RequestID || exp.Session != req.Session || exp.Limit != req.Limit {
+		t.Errorf("expected `%v`, got `%v`", exp, *req)
+	}
+}
+
+func TestBindRequest_SourcePrecedence(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Limit int `queryparam:"limit" header:"X-Limit" default:"10"`
+	}
+
+	tests := []struct {
+		Name    string
+		Sources []queryparam.Source
+		URL     string
+		Header  string
+		Exp     int
+	}{
+		{Name: "QueryFirst", Sources: []queryparam.Source{queryparam.SourceQuery, queryparam.SourceHeader}, URL: "/?limit=1", Header: "2", Exp: 1},
+		{Name: "HeaderFirst", Sources: []queryparam.Source{queryparam.SourceHeader, queryparam.SourceQuery}, URL: "/?limit=1", Header: "2", Exp: 2},
+		{Name: "FallbackToHeader", Sources: []queryparam.Source{queryparam.SourceQuery, queryparam.SourceHeader}, URL: "/", Header: "2", Exp: 2},
+		{Name: "SourceNotRead", Sources: []queryparam.Source{queryparam.SourceQuery}, URL: "/", Header: "2", Exp: 10},
+		{Name: "NilSourcesReadQuery", Sources: nil, URL: "/?limit=1", Header: "2", Exp: 1},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.Name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tc.URL, nil)
+			r.Header.Set("X-Limit", tc.Header)
+			req := &request{}
+			if err := newSourceParser(tc.Sources...).BindRequest(r, req); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if tc.Exp != req.Limit {
+				t.Errorf("expected %d, got %d", tc.Exp, req.Limit)
+			}
+		})
+	}
+}
+
+func TestBindRequest_NestedSources(t *testing.T) {
+	t.Parallel()
+
+	type page struct {
+		Size   int `queryparam:"size" header:"Size"`
+		Number int `queryparam:"number"`
+	}
+	type request struct {
+		Page page `queryparam:"page" header:"X-Page"`
+	}
+	parser := newSourceParser(queryparam.SourceHeader, queryparam.SourceQuery)
+
+	t.Run("Query", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?page.size=5&page.number=2", nil)
+		req := &request{}
+		if err := parser.BindRequest(r, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := (page{Size: 5, Number: 2}); exp != req.Page {
+			t.Errorf("expected `%v`, got `%v`", exp, req.Page)
+		}
+	})
+	t.Run("Header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?page.size=5", nil)
+		r.Header.Set("X-Page.Size", "7")
+		req := &request{}
+		if err := parser.BindRequest(r, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp := (page{Size: 7}); exp != req.Page {
+			t.Errorf("expected `%v`, got `%v`", exp, req.Page)
+		}
+	})
+	t.Run("UntaggedSource", func(t *testing.T) {
+		// the nested struct is not tagged for cookies, so its fields cannot be read from them.
+		type cookieRequest struct {
+			Page struct {
+				Size int `queryparam:"size" cookie:"size"`
+			} `queryparam:"page"`
+		}
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.AddCookie(&http.Cookie{Name: "size", Value: "3"})
+		req := &cookieRequest{}
+		if err := queryparam.BindRequest(r, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if req.Page.Size != 0 {
+			t.Errorf("expected size to be unset, got %d", req.Page.Size)
+		}
+	})
+}
+
+func TestBindRequest_SourceErrors(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Limit int    `queryparam:"limit" header:"X-Limit" max:"100"`
+		Name  string `cookie:"name" required:"true"`
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Limit", "x")
+	err := queryparam.BindRequest(r, &request{})
+
+	var invalidParameterValue *queryparam.ErrInvalidParameterValue
+	if !errors.As(err, &invalidParameterValue) {
+		t.Errorf("expected *ErrInvalidParameterValue, got %T: %v", err, err)
+		return
+	}
+	if exp, got := "X-Limit", invalidParameterValue.Parameter; exp != got {
+		t.Errorf("expected parameter %s, got %s", exp, got)
+	}
+	var validation *queryparam.ErrValidation
+	if !errors.As(err, &validation) {
+		t.Errorf("expected *ErrValidation, got %T: %v", err, err)
+		return
+	}
+	if exp, got := "name", validation.Parameter; exp != got {
+		t.Errorf("expected parameter %s, got %s", exp, got)
+	}
+}
+
+func TestBindRequest_FormNotRead(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/?name=tom", strings.NewReader("name=bob"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req := &httpRequest{}
+	if err := queryparam.BindRequest(r, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if exp, got := "tom", req.Name; exp != got {
+		t.Errorf("expected %s, got %s", exp, got)
+	}
+	body, _ := io.ReadAll(r.Body)
+	if exp, got := "name=bob", string(body); exp != got {
+		t.Errorf("expected body to be unread. expected `%s`, got `%s`", exp, got)
+	}
+}
+
+func TestParse_IgnoresOtherSources(t *testing.T) {
+	t.Parallel()
+
+	req := &sourceRequest{RequestID: "abc"}
+	if err := queryparam.Parse(url.Values{"name": {"tom"}, "X-Request-Id": {"def"}}, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if req.Name != "tom" || req.RequestID != "abc" || req.Limit != 10 {
+		t.Errorf("unexpected result: %v", *req)
+	}
+}
+
+func TestBindRequest_InvalidSourceTag(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Name string `header:"X-Name,remain"`
+	}
+
+	err := queryparam.BindRequest(httptest.NewRequest(http.MethodGet, "/", nil), &request{})
+	if !errors.Is(err, queryparam.ErrInvalidTag) {
+		t.Errorf("expected ErrInvalidTag, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package queryparam
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownParameter is returned by a strict Parser when the query contains parameters that
+// are not mapped to any field.
+type ErrUnknownParameter struct {
+	// Parameters are the unknown parameter names, sorted alphabetically.
+	Parameters []string
+	// Suggestions contains known parameter names similar to each unknown parameter, closest first.
+	Suggestions map[string][]string
+}
+
+// Error returns the full error message.
+func (e *ErrUnknownParameter) Error() string {
+	messages := make([]string, len(e.Parameters))
+	for i, parameter := range e.Parameters {
+		messages[i] = parameter
+		if suggestions := e.Suggestions[parameter]; len(suggestions) > 0 {
+			messages[i] += fmt.Sprintf(" (did you mean %s?)", strings.Join(suggestions, " or "))
+		}
+	}
+	return fmt.Sprintf("unknown parameters: %s", strings.Join(messages, ", "))
+}
+
+// maxSuggestions is the maximum number of suggestions given for each unknown parameter.
+const maxSuggestions = 3
+
+// checkUnknownParameters returns an *ErrUnknownParameter if urlValues contains any parameters
+// that are not in the known set.
+func checkUnknownParameters(urlValues url.Values, known map[string]struct{}) error {
+	var unknown []string
+	for name := range urlValues {
+		if _, ok := known[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Strings(unknown)
+
+	suggestions := make(map[string][]string, len(unknown))
+	for _, name := range unknown {
+		if s := suggest(name, known); len(s) > 0 {
+			suggestions[name] = s
+		}
+	}
+	return &ErrUnknownParameter{
+		Parameters:  unknown,
+		Suggestions: suggestions,
+	}
+}
+
+// suggest returns the known names that are within a small edit distance of the given name.
+func suggest(name string, known map[string]struct{}) []string {
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	distances := make(map[string]int)
+	var res []string
+	for knownName := range known {
+		if d := editDistance(name, knownName); d <= maxDistance {
+			distances[knownName] = d
+			res = append(res, knownName)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if distances[res[i]] != distances[res[j]] {
+			return distances[res[i]] < distances[res[j]]
+		}
+		return res[i] < res[j]
+	})
+	if len(res) > maxSuggestions {
+		res = res[:maxSuggestions]
+	}
+	return res
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a string, b string) int {
+	ar, br := []rune(a), []rune(b)
+	previous := make([]int, len(br)+1)
+	current := make([]int, len(br)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		current[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(br)]
+}
+
+// min3 returns the smallest of the given ints.
+func min3(a int, b int, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
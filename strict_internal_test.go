@@ -0,0 +1,25 @@
+package queryparam
+
+import (
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		A   string
+		B   string
+		Exp int
+	}{
+		{A: "", B: "", Exp: 0},
+		{A: "limit", B: "limit", Exp: 0},
+		{A: "limt", B: "limit", Exp: 1},
+		{A: "page.szie", B: "page.size", Exp: 2},
+		{A: "kitten", B: "sitting", Exp: 3},
+		{A: "", B: "abc", Exp: 3},
+	}
+	for _, tc := range tests {
+		if got := editDistance(tc.A, tc.B); tc.Exp != got {
+			t.Errorf("expected distance between %s and %s to be %d, got %d", tc.A, tc.B, tc.Exp, got)
+		}
+	}
+}
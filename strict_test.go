@@ -0,0 +1,84 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func newStrictParser() *queryparam.Parser {
+	return &queryparam.Parser{
+		Tag:          "queryparam",
+		DelimiterTag: "queryparamdelim",
+		Delimiter:    ",",
+		Separator:    ".",
+		Strict:       true,
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+	}
+}
+
+type strictRequest struct {
+	Limit  int       `queryparam:"limit"`
+	Offset int       `queryparam:"offset"`
+	Page   parsePage `queryparam:"page"`
+}
+
+func TestParse_Strict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Known", func(t *testing.T) {
+		urlValues := url.Values{
+			"limit":     []string{"10"},
+			"page.size": []string{"10"},
+		}
+		if err := newStrictParser().Parse(urlValues, &strictRequest{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		urlValues := url.Values{
+			"limt":      []string{"10"},
+			"page.szie": []string{"10"},
+			"something": []string{"else"},
+		}
+		err := newStrictParser().Parse(urlValues, &strictRequest{})
+		var unknownErr *queryparam.ErrUnknownParameter
+		if !errors.As(err, &unknownErr) {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if exp, got := []string{"limt", "page.szie", "something"}, unknownErr.Parameters; !reflect.DeepEqual(exp, got) {
+			t.Errorf("unexpected parameters. expected `%v`, got `%v`", exp, got)
+		}
+		expSuggestions := map[string][]string{
+			"limt":      {"limit"},
+			"page.szie": {"page.size"},
+		}
+		if got := unknownErr.Suggestions; !reflect.DeepEqual(expSuggestions, got) {
+			t.Errorf("unexpected suggestions. expected `%v`, got `%v`", expSuggestions, got)
+		}
+		exp := "unknown parameters: limt (did you mean limit?), page.szie (did you mean page.size?), something"
+		if got := err.Error(); exp != got {
+			t.Errorf("expected `%s`, got `%s`", exp, got)
+		}
+	})
+	t.Run("ParseAll", func(t *testing.T) {
+		urlValues := url.Values{
+			"limt":   []string{"10"},
+			"offset": []string{"x"},
+		}
+		err := newStrictParser().ParseAll(urlValues, &strictRequest{})
+		var errs *queryparam.ErrInvalidParameters
+		if !errors.As(err, &errs) {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		byParameter := errs.ByParameter()
+		if len(byParameter["limt"]) != 1 || len(byParameter["offset"]) != 1 {
+			t.Errorf("unexpected errors: %v", byParameter)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package queryparam
+
+import (
+	"encoding"
+	"net/url"
+	"reflect"
+)
+
+// Unmarshaler is implemented by types that can parse a query parameter value into themselves.
+// UnmarshalQueryParam is called with the raw parameter value, which is empty if the
+// parameter is absent, and the delimiter for the field.
+// Unmarshaler takes precedence over any parser registered in Parser.ValueParsers.
+type Unmarshaler interface {
+	UnmarshalQueryParam(value string, delimiter string) error
+}
+
+// Marshaler is implemented by types that can encode themselves into a query parameter value.
+// Marshaler takes precedence over any encoder registered in Parser.ValueEncoders.
+type Marshaler interface {
+	MarshalQueryParam(delimiter string) (string, error)
+}
+
+// QueryUnmarshaler is implemented by parse targets that need to look at the query parameters
+// as a whole, e.g. a parameter that may be given as one value or as several.
+// UnmarshalQuery is called by Parser.Parse after the tagged fields have been parsed, so it
+// can use or override their values. Untagged fields are left for UnmarshalQuery to populate.
+type QueryUnmarshaler interface {
+	UnmarshalQuery(urlValues url.Values) error
+}
+
+var (
+	unmarshalerType     = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	marshalerType       = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// unmarshalerValueParser returns a ValueParser that parses values using UnmarshalQueryParam.
+func unmarshalerValueParser(t reflect.Type) ValueParser {
+	return func(value string, delimiter string) (reflect.Value, error) {
+		target := reflect.New(t)
+		err := target.Interface().(Unmarshaler).UnmarshalQueryParam(value, delimiter)
+		return target.Elem(), err
+	}
+}
+
+// textUnmarshalerValueParser returns a ValueParser that parses values using UnmarshalText.
+func textUnmarshalerValueParser(t reflect.Type) ValueParser {
+	return func(value string, _ string) (reflect.Value, error) {
+		target := reflect.New(t)
+		if value == "" {
+			// ignore blank values.
+			return target.Elem(), nil
+		}
+		err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+		return target.Elem(), err
+	}
+}
+
+// addressable returns an addressable copy of the given value if it is not already addressable,
+// so that methods with pointer receivers can be called on it.
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value
+	}
+	res := reflect.New(value.Type()).Elem()
+	res.Set(value)
+	return res
+}
+
+// marshalerValueEncoder encodes a value using MarshalQueryParam.
+func marshalerValueEncoder(value reflect.Value, delimiter string) (string, error) {
+	return addressable(value).Addr().Interface().(Marshaler).MarshalQueryParam(delimiter)
+}
+
+// textMarshalerValueEncoder encodes a value using MarshalText.
+func textMarshalerValueEncoder(value reflect.Value, _ string) (string, error) {
+	text, err := addressable(value).Addr().Interface().(encoding.TextMarshaler).MarshalText()
+	return string(text), err
+}
@@ -0,0 +1,213 @@
+package queryparam_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/tomwright/queryparam/v4"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type textLevel int
+
+func (l *textLevel) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return fmt.Errorf("unknown level: %s", text)
+	}
+	return nil
+}
+
+func (l textLevel) MarshalText() ([]byte, error) {
+	switch l {
+	case 1:
+		return []byte("low"), nil
+	case 2:
+		return []byte("high"), nil
+	default:
+		return nil, nil
+	}
+}
+
+// bbox implements queryparam.Unmarshaler and queryparam.Marshaler.
+type bbox struct {
+	Points []string
+}
+
+func (b *bbox) UnmarshalQueryParam(value string, delimiter string) error {
+	if value == "" {
+		return nil
+	}
+	b.Points = strings.Split(value, delimiter)
+	if len(b.Points) != 4 {
+		return errors.New("bbox must have 4 points")
+	}
+	return nil
+}
+
+func (b *bbox) MarshalQueryParam(delimiter string) (string, error) {
+	return strings.Join(b.Points, delimiter), nil
+}
+
+type unmarshalerRequest struct {
+	Level   textLevel   `queryparam:"level"`
+	Levels  []textLevel `queryparam:"levels"`
+	IP      net.IP      `queryparam:"ip"`
+	BBox    bbox        `queryparam:"bbox" queryparamdelim:"|"`
+	BBoxPtr *bbox       `queryparam:"bbox-ptr"`
+}
+
+func TestParse_Unmarshaler(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{
+		"level":    []string{"high"},
+		"levels":   []string{"low,high"},
+		"ip":       []string{"127.0.0.1", "10.0.0.1"},
+		"bbox":     []string{"1|2|3|4"},
+		"bbox-ptr": []string{"5,6,7,8"},
+	}
+
+	req := &unmarshalerRequest{}
+	if err := queryparam.Parse(urlValues, req); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	exp := &unmarshalerRequest{
+		Level:   2,
+		Levels:  []textLevel{1, 2},
+		IP:      net.ParseIP("127.0.0.1"),
+		BBox:    bbox{Points: []string{"1", "2", "3", "4"}},
+		BBoxPtr: &bbox{Points: []string{"5", "6", "7", "8"}},
+	}
+	if !reflect.DeepEqual(exp, req) {
+		t.Errorf("expected `%v`, got `%v`", exp, req)
+	}
+}
+
+func TestParse_UnmarshalerError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("TextUnmarshaler", func(t *testing.T) {
+		err := queryparam.Parse(url.Values{"level": []string{"medium"}}, &unmarshalerRequest{})
+		var paramErr *queryparam.ErrInvalidParameterValue
+		if !errors.As(err, &paramErr) || paramErr.Parameter != "level" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("Unmarshaler", func(t *testing.T) {
+		err := queryparam.Parse(url.Values{"bbox": []string{"1|2"}}, &unmarshalerRequest{})
+		var paramErr *queryparam.ErrInvalidParameterValue
+		if !errors.As(err, &paramErr) || paramErr.Parameter != "bbox" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEncode_Marshaler(t *testing.T) {
+	t.Parallel()
+
+	req := unmarshalerRequest{
+		Level:  1,
+		Levels: []textLevel{1, 2},
+		IP:     net.ParseIP("127.0.0.1"),
+		BBox:   bbox{Points: []string{"1", "2", "3", "4"}},
+	}
+
+	urlValues, err := queryparam.Encode(req)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	exp := url.Values{
+		"level":  []string{"low"},
+		"levels": []string{"low,high"},
+		"ip":     []string{"127.0.0.1"},
+		"bbox":   []string{"1|2|3|4"},
+	}
+	if !reflect.DeepEqual(exp, urlValues) {
+		t.Errorf("expected `%v`, got `%v`", exp, urlValues)
+	}
+}
+
+// boundsRequest accepts a bounding box either as a single bbox parameter or as
+// four separate parameters.
+type boundsRequest struct {
+	Limit int `queryparam:"limit"`
+	BBox  [4]float64
+}
+
+func (r *boundsRequest) UnmarshalQuery(urlValues url.Values) error {
+	names := []string{"min-x", "min-y", "max-x", "max-y"}
+	values := make([]string, len(names))
+	if bbox := urlValues.Get("bbox"); bbox != "" {
+		values = strings.Split(bbox, ",")
+		if len(values) != len(names) {
+			return &queryparam.ErrInvalidParameterValue{
+				Err:       errors.New("bbox must have 4 points"),
+				Parameter: "bbox",
+				Field:     "BBox",
+				Value:     bbox,
+				Type:      reflect.TypeOf(r.BBox),
+			}
+		}
+	} else {
+		for i, name := range names {
+			values[i] = urlValues.Get(name)
+		}
+	}
+	for i, value := range values {
+		parsed, err := queryparam.Float64ValueParser(value, "")
+		if err != nil {
+			return err
+		}
+		r.BBox[i] = parsed.Float()
+	}
+	return nil
+}
+
+func TestParse_QueryUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	tests := []url.Values{
+		{"limit": []string{"10"}, "bbox": []string{"1,2,3,4"}},
+		{"limit": []string{"10"}, "min-x": []string{"1"}, "min-y": []string{"2"}, "max-x": []string{"3"}, "max-y": []string{"4"}},
+	}
+
+	for _, urlValues := range tests {
+		req := &boundsRequest{}
+		if err := queryparam.Parse(urlValues, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			continue
+		}
+		exp := &boundsRequest{Limit: 10, BBox: [4]float64{1, 2, 3, 4}}
+		if !reflect.DeepEqual(exp, req) {
+			t.Errorf("expected `%v`, got `%v`", exp, req)
+		}
+	}
+}
+
+func TestParseAll_QueryUnmarshalerError(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{"limit": []string{"x"}, "bbox": []string{"1,2"}}
+
+	err := queryparam.ParseAll(urlValues, &boundsRequest{})
+	var errs *queryparam.ErrInvalidParameters
+	if !errors.As(err, &errs) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	byParameter := errs.ByParameter()
+	if len(byParameter["limit"]) != 1 || len(byParameter["bbox"]) != 1 {
+		t.Errorf("unexpected errors: %v", byParameter)
+	}
+}
@@ -0,0 +1,291 @@
+package queryparam
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// By default validation rules are set using the following struct tags, which can be renamed
+// using Parser.RuleTags:
+//
+//	required:"true"      the parameter must be present.
+//	min:"1" max:"100"    numbers and times must be within the given bounds. Times are given in the
+//	                     same format that is accepted by the field's value parser.
+//	minlen:"1"           strings must have at least the given number of characters, slices at
+//	maxlen:"10"          least the given number of items, and at most for maxlen.
+//	oneof:"asc desc"     the value must be one of the space separated options.
+//	pattern:"^[a-z]+$"   strings must match the given regular expression.
+//
+// For slice fields, every rule other than minlen and maxlen is checked against each item.
+// Pointer and Optional fields are validated using their value, and nil or null values are
+// not validated.
+// Rules other than required are only checked if the parameter is present or has a default value.
+const (
+	RuleRequired = "required"
+	RuleMin      = "min"
+	RuleMax      = "max"
+	RuleMinLen   = "minlen"
+	RuleMaxLen   = "maxlen"
+	RuleOneOf    = "oneof"
+	RulePattern  = "pattern"
+)
+
+// DefaultRuleTags returns the struct tag names used to set each validation rule, keyed by
+// rule name.
+func DefaultRuleTags() map[string]string {
+	return map[string]string{
+		RuleRequired: RuleRequired,
+		RuleMin:      RuleMin,
+		RuleMax:      RuleMax,
+		RuleMinLen:   RuleMinLen,
+		RuleMaxLen:   RuleMaxLen,
+		RuleOneOf:    RuleOneOf,
+		RulePattern:  RulePattern,
+	}
+}
+
+// ErrValidation is returned when a parsed value does not pass a validation rule.
+type ErrValidation struct {
+	Parameter string
+	Field     string
+	// Rule is the name of the rule that failed, e.g. min.
+	Rule string
+	// Arg is the value of the rule tag, e.g. 1 for min:"1".
+	Arg string
+	// Value is the value that failed the rule. For rules that are checked against each item of
+	// a slice, this is the failing item.
+	Value string
+	Type  reflect.Type
+}
+
+// Error returns the full error message.
+func (e *ErrValidation) Error() string {
+	return fmt.Sprintf("validation failed for field %s (%s) from parameter %s (%s): %s=%s", e.Field, e.Type, e.Parameter, e.Value, e.Rule, e.Arg)
+}
+
+// validationRule is a compiled validation rule.
+type validationRule struct {
+	name string
+	arg  string
+	// each is true if the rule is checked against each item of a slice rather than the slice itself.
+	each  bool
+	check func(value reflect.Value) bool
+}
+
+// ruleArg returns the arg of the given rule from the field tag, and whether the rule is set.
+func (p *Parser) ruleArg(field reflect.StructField, rule string) (string, bool) {
+	tagName := p.RuleTags[rule]
+	if tagName == "" {
+		return "", false
+	}
+	return field.Tag.Lookup(tagName)
+}
+
+// compileValidationRules compiles the validation rules for the given field.
+func (p *Parser) compileValidationRules(field reflect.StructField) (bool, []validationRule, error) {
+	required := false
+	// an empty required rule, e.g. `required:""`, is treated as true.
+	if arg, ok := p.ruleArg(field, RuleRequired); ok && arg == "" {
+		required = true
+	} else if ok {
+		var err error
+		if required, err = strconv.ParseBool(arg); err != nil {
+			return false, nil, fmt.Errorf("invalid %s rule %q for field: %s: %w", RuleRequired, arg, field.Name, ErrInvalidTag)
+		}
+	}
+
+	t := valueType(field.Type)
+	itemType, each := t, false
+	if isSliceType(t) {
+		itemType, each = t.Elem(), true
+	}
+
+	var rules []validationRule
+	for _, name := range []string{RuleMin, RuleMax, RuleMinLen, RuleMaxLen, RuleOneOf, RulePattern} {
+		arg, ok := p.ruleArg(field, name)
+		if !ok {
+			continue
+		}
+		var check func(value reflect.Value) bool
+		var err error
+		ruleEach := each
+		switch name {
+		case RuleMin, RuleMax:
+			var compare func(value reflect.Value) int
+			if compare, err = p.compareRule(itemType, arg); err == nil {
+				if name == RuleMin {
+					check = func(value reflect.Value) bool { return compare(value) >= 0 }
+				} else {
+					check = func(value reflect.Value) bool { return compare(value) <= 0 }
+				}
+			}
+		case RuleMinLen, RuleMaxLen:
+			ruleEach = false
+			var length func(value reflect.Value) int
+			var limit int
+			if length, err = lengthRule(t); err == nil {
+				if limit, err = strconv.Atoi(arg); err == nil {
+					if name == RuleMinLen {
+						check = func(value reflect.Value) bool { return length(value) >= limit }
+					} else {
+						check = func(value reflect.Value) bool { return length(value) <= limit }
+					}
+				}
+			}
+		case RuleOneOf:
+			options := strings.Fields(arg)
+			check = func(value reflect.Value) bool {
+				formatted := fmt.Sprint(value.Interface())
+				for _, option := range options {
+					if formatted == option {
+						return true
+					}
+				}
+				return false
+			}
+		case RulePattern:
+			var pattern *regexp.Regexp
+			if itemType.Kind() != reflect.String {
+				err = fmt.Errorf("%w: %v", ErrUnhandledFieldType, itemType)
+			} else if pattern, err = regexp.Compile(arg); err == nil {
+				check = func(value reflect.Value) bool { return pattern.MatchString(value.String()) }
+			}
+		}
+		if err != nil {
+			return false, nil, fmt.Errorf("invalid %s rule %q for field: %s: %v: %w", name, arg, field.Name, err, ErrInvalidTag)
+		}
+		rules = append(rules, validationRule{name: name, arg: arg, each: ruleEach, check: check})
+	}
+
+	return required, rules, nil
+}
+
+// compareRule returns a func that compares a value of the given type with the given arg, returning
+// -1, 0 or +1 if the value is less than, equal to or greater than the arg.
+func (p *Parser) compareRule(t reflect.Type, arg string) (func(value reflect.Value) int, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		limit, err := strconv.ParseInt(arg, 10, 64)
+		return func(value reflect.Value) int { return compareInt64(value.Int(), limit) }, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		limit, err := strconv.ParseUint(arg, 10, 64)
+		return func(value reflect.Value) int { return compareUint64(value.Uint(), limit) }, err
+	case reflect.Float32, reflect.Float64:
+		limit, err := strconv.ParseFloat(arg, 64)
+		return func(value reflect.Value) int { return compareFloat64(value.Float(), limit) }, err
+	}
+
+	if t == reflect.TypeOf(time.Time{}) {
+		valueParser, ok := p.valueParser(t)
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrUnhandledFieldType, t)
+		}
+		parsedLimit, err := valueParser(arg, "")
+		if err != nil {
+			return nil, err
+		}
+		limit := parsedLimit.Interface().(time.Time)
+		return func(value reflect.Value) int {
+			if v := value.Interface().(time.Time); v.Before(limit) {
+				return -1
+			} else if v.After(limit) {
+				return 1
+			}
+			return 0
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %v", ErrUnhandledFieldType, t)
+}
+
+// compareInt64 compares a and b, returning -1, 0 or +1.
+func compareInt64(a int64, b int64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// compareUint64 compares a and b, returning -1, 0 or +1.
+func compareUint64(a uint64, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// compareFloat64 compares a and b, returning -1, 0 or +1.
+func compareFloat64(a float64, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// lengthRule returns a func that returns the length of a value of the given type.
+func lengthRule(t reflect.Type) (func(value reflect.Value) int, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return func(value reflect.Value) int { return utf8.RuneCountInString(value.String()) }, nil
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return func(value reflect.Value) int { return value.Len() }, nil
+	default:
+		return nil, fmt.Errorf("%w: %v", ErrUnhandledFieldType, t)
+	}
+}
+
+// validate checks the given field value, read from the named parameter, against the compiled
+// validation rules.
+func (fieldPlan *fieldPlan) validate(value reflect.Value, name string, queryParameterValue string) error {
+	for value.Kind() == reflect.Ptr || isOptionalType(value.Type()) {
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+			continue
+		}
+		if !value.Field(optionalSetIndex).Bool() || value.Field(optionalNullIndex).Bool() {
+			return nil
+		}
+		value = value.Field(optionalValueIndex)
+	}
+
+	for _, rule := range fieldPlan.rules {
+		if !rule.each {
+			if !rule.check(value) {
+				return fieldPlan.validationError(name, rule.name, rule.arg, queryParameterValue)
+			}
+			continue
+		}
+		for i := 0; i < value.Len(); i++ {
+			if item := value.Index(i); !rule.check(item) {
+				return fieldPlan.validationError(name, rule.name, rule.arg, fmt.Sprint(item.Interface()))
+			}
+		}
+	}
+	return nil
+}
+
+// validationError returns an *ErrValidation for the field, read from the named parameter.
+func (fieldPlan *fieldPlan) validationError(name string, rule string, arg string, value string) error {
+	return &ErrValidation{
+		Parameter: name,
+		Field:     fieldPlan.field.Name,
+		Rule:      rule,
+		Arg:       arg,
+		Value:     value,
+		Type:      fieldPlan.field.Type,
+	}
+}
@@ -0,0 +1,227 @@
+package queryparam_test
+
+import (
+	"errors"
+	"github.com/tomwright/queryparam/v4"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type validateRequest struct {
+	Query   string     `queryparam:"q" required:"true" minlen:"2" maxlen:"5"`
+	Limit   int        `queryparam:"limit" min:"1" max:"100" default:"25"`
+	Offset  *int64     `queryparam:"offset" max:"1000"`
+	Score   float64    `queryparam:"score" min:"0.5"`
+	Sort    string     `queryparam:"sort" oneof:"asc desc"`
+	IDs     []int      `queryparam:"id" minlen:"1" maxlen:"3" min:"1"`
+	Tags    []string   `queryparam:"tag" pattern:"^[a-z]+$"`
+	Since   time.Time  `queryparam:"since" min:"2019-01-01T00:00:00Z"`
+	Until   *time.Time `queryparam:"until" max:"2020-01-01T00:00:00Z"`
+	Unbound int        `queryparam:"unbound" required:"false"`
+}
+
+func TestParse_Validation(t *testing.T) {
+	t.Parallel()
+
+	valid := url.Values{
+		"q":      []string{"tom"},
+		"offset": []string{"10"},
+		"score":  []string{"0.5"},
+		"sort":   []string{"asc"},
+		"id":     []string{"1,2,3"},
+		"tag":    []string{"a,bc"},
+		"since":  []string{"2019-02-05T13:32:02Z"},
+		"until":  []string{"2019-02-05T13:32:02Z"},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		req := &validateRequest{}
+		if err := queryparam.Parse(valid, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+	t.Run("OptionalParametersNotValidated", func(t *testing.T) {
+		req := &validateRequest{}
+		if err := queryparam.Parse(url.Values{"q": []string{"tom"}}, req); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	tests := []struct {
+		Name      string
+		Parameter string
+		Value     []string
+		Rule      string
+		ErrValue  string
+	}{
+		{Name: "Required", Parameter: "q", Value: nil, Rule: queryparam.RuleRequired},
+		{Name: "MinLen", Parameter: "q", Value: []string{"t"}, Rule: queryparam.RuleMinLen, ErrValue: "t"},
+		{Name: "MaxLen", Parameter: "q", Value: []string{"tomtom"}, Rule: queryparam.RuleMaxLen, ErrValue: "tomtom"},
+		{Name: "Min", Parameter: "limit", Value: []string{"0"}, Rule: queryparam.RuleMin, ErrValue: "0"},
+		{Name: "Max", Parameter: "limit", Value: []string{"101"}, Rule: queryparam.RuleMax, ErrValue: "101"},
+		{Name: "MaxPointer", Parameter: "offset", Value: []string{"1001"}, Rule: queryparam.RuleMax, ErrValue: "1001"},
+		{Name: "MinFloat", Parameter: "score", Value: []string{"0.4"}, Rule: queryparam.RuleMin, ErrValue: "0.4"},
+		{Name: "OneOf", Parameter: "sort", Value: []string{"up"}, Rule: queryparam.RuleOneOf, ErrValue: "up"},
+		{Name: "SliceMinLen", Parameter: "id", Value: []string{""}, Rule: queryparam.RuleMinLen},
+		{Name: "SliceMaxLen", Parameter: "id", Value: []string{"1,2,3,4"}, Rule: queryparam.RuleMaxLen, ErrValue: "1,2,3,4"},
+		{Name: "SliceItemMin", Parameter: "id", Value: []string{"1,0"}, Rule: queryparam.RuleMin, ErrValue: "0"},
+		{Name: "SliceItemPattern", Parameter: "tag", Value: []string{"a", "B"}, Rule: queryparam.RulePattern, ErrValue: "B"},
+		{Name: "MinTime", Parameter: "since", Value: []string{"2018-02-05T13:32:02Z"}, Rule: queryparam.RuleMin, ErrValue: "2018-02-05T13:32:02Z"},
+		{Name: "MaxTime", Parameter: "until", Value: []string{"2021-02-05T13:32:02Z"}, Rule: queryparam.RuleMax, ErrValue: "2021-02-05T13:32:02Z"},
+	}
+
+	for _, testCase := range tests {
+		tc := testCase
+		t.Run(tc.Name, func(t *testing.T) {
+			urlValues := url.Values{}
+			for k, v := range valid {
+				urlValues[k] = v
+			}
+			if tc.Value == nil {
+				delete(urlValues, tc.Parameter)
+			} else {
+				urlValues[tc.Parameter] = tc.Value
+			}
+
+			err := queryparam.Parse(urlValues, &validateRequest{})
+			var validationErr *queryparam.ErrValidation
+			if !errors.As(err, &validationErr) {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if validationErr.Parameter != tc.Parameter || validationErr.Rule != tc.Rule || validationErr.Value != tc.ErrValue {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseAll_Validation(t *testing.T) {
+	t.Parallel()
+
+	urlValues := url.Values{
+		"limit": []string{"0"},
+		"sort":  []string{"up"},
+	}
+
+	err := queryparam.ParseAll(urlValues, &validateRequest{})
+	var errs *queryparam.ErrInvalidParameters
+	if !errors.As(err, &errs) {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	byParameter := errs.ByParameter()
+	for _, parameter := range []string{"q", "limit", "sort"} {
+		if len(byParameter[parameter]) != 1 {
+			t.Errorf("expected 1 error for parameter %s, got %v", parameter, byParameter[parameter])
+		}
+	}
+}
+
+func TestParse_InvalidValidationTag(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]interface{}{
+		"Required": &struct {
+			Name string `queryparam:"name" required:"yes please"`
+		}{},
+		"MinString": &struct {
+			Name string `queryparam:"name" min:"1"`
+		}{},
+		"MinInvalid": &struct {
+			Age int `queryparam:"age" min:"one"`
+		}{},
+		"MinLenInt": &struct {
+			Age int `queryparam:"age" minlen:"1"`
+		}{},
+		"PatternInt": &struct {
+			Age int `queryparam:"age" pattern:"^1$"`
+		}{},
+		"PatternInvalid": &struct {
+			Name string `queryparam:"name" pattern:"("`
+		}{},
+		"RequiredWithDefault": &struct {
+			Age int `queryparam:"age" required:"true" default:"3"`
+		}{},
+	}
+
+	for name, req := range tests {
+		req := req
+		t.Run(name, func(t *testing.T) {
+			err := queryparam.Parse(url.Values{}, req)
+			if !errors.Is(err, queryparam.ErrInvalidTag) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParse_EmptyRequiredTag(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Name string `queryparam:"name" required:""`
+	}
+
+	err := queryparam.Parse(url.Values{}, &request{})
+	var validation *queryparam.ErrValidation
+	if !errors.As(err, &validation) || validation.Rule != queryparam.RuleRequired {
+		t.Errorf("expected required validation error, got %v", err)
+	}
+
+	if err := queryparam.Parse(url.Values{"name": []string{"tom"}}, &request{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParse_RuleTags(t *testing.T) {
+	t.Parallel()
+
+	parser := &queryparam.Parser{
+		Tag:          "queryparam",
+		Delimiter:    ",",
+		ValueParsers: queryparam.DefaultValueParsers(),
+		ValueSetters: queryparam.DefaultValueSetters(),
+		RuleTags: map[string]string{
+			queryparam.RuleRequired: "validate_required",
+			queryparam.RuleMax:      "validate_max",
+		},
+	}
+	type request struct {
+		Name string `queryparam:"name" validate_required:"true"`
+		Age  int    `queryparam:"age" validate_max:"10" min:"5"`
+	}
+
+	err := parser.Parse(url.Values{"age": []string{"1"}}, &request{})
+	var validation *queryparam.ErrValidation
+	if !errors.As(err, &validation) || validation.Rule != queryparam.RuleRequired {
+		t.Errorf("expected required validation error, got %v", err)
+	}
+
+	err = parser.Parse(url.Values{"name": []string{"tom"}, "age": []string{"11"}}, &request{})
+	if !errors.As(err, &validation) || validation.Rule != queryparam.RuleMax {
+		t.Errorf("expected max validation error, got %v", err)
+	}
+
+	// min has no tag name so it is not checked.
+	if err := parser.Parse(url.Values{"name": []string{"tom"}, "age": []string{"1"}}, &request{}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestErrValidation_Error(t *testing.T) {
+	e := &queryparam.ErrValidation{
+		Parameter: "limit",
+		Field:     "Limit",
+		Rule:      queryparam.RuleMin,
+		Arg:       "1",
+		Value:     "0",
+		Type:      reflect.TypeOf(0),
+	}
+	exp := "validation failed for field Limit (int) from parameter limit (0): min=1"
+	if got := e.Error(); exp != got {
+		t.Errorf("expected `%s`, got `%s`", exp, got)
+	}
+}